@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// lookupKind is a type of object whose IDs are cached by natural key.
+type lookupKind string
+
+const (
+	lookupGroup      lookupKind = "group"
+	lookupRole       lookupKind = "role"
+	lookupMarking    lookupKind = "marking definition"
+	lookupCapability lookupKind = "capability"
+	lookupUser       lookupKind = "user"
+)
+
+// lookupCache caches the IDs of the objects by natural key for the duration of a Terraform run.
+// It is shared by all the resources and safe for concurrent use.
+type lookupCache struct {
+	mu      sync.RWMutex
+	entries map[lookupKind]map[string]string
+}
+
+// newLookupCache returns an empty lookup cache.
+func newLookupCache() *lookupCache {
+	return &lookupCache{
+		entries: map[lookupKind]map[string]string{},
+	}
+}
+
+// get returns the cached ID of an object.
+func (c *lookupCache) get(kind lookupKind, name string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	id, ok := c.entries[kind][name]
+
+	return id, ok
+}
+
+// set caches the ID of an object, e.g. after its creation.
+func (c *lookupCache) set(kind lookupKind, name, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries[kind] == nil {
+		c.entries[kind] = map[string]string{}
+	}
+
+	c.entries[kind][name] = id
+}
+
+// remove forgets the ID of an object, e.g. after its deletion.
+func (c *lookupCache) remove(kind lookupKind, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries[kind], name)
+}
+
+// lookupID returns the ID of an object by its natural key, e.g. the name of a group or the email of a user.
+// The object is resolved by opencti when it is not cached yet, found is false when it does not exist.
+func (d *openctiProviderData) lookupID(ctx context.Context, kind lookupKind, key string) (string, bool, error) {
+	if id, ok := d.cache.get(kind, key); ok {
+		return id, true, nil
+	}
+
+	id, found, err := resolveID(ctx, d.graphql, kind, key)
+	if err != nil || !found {
+		return "", false, err
+	}
+
+	d.cache.set(kind, key, id)
+
+	return id, true, nil
+}
+
+// capabilityIDs returns the IDs of the capabilities in the same order as their names.
+// The capabilities are all requested at once when one of them is not cached yet.
+func (d *openctiProviderData) capabilityIDs(ctx context.Context, names []string) ([]string, error) {
+	uncached := slices.ContainsFunc(names, func(name string) bool {
+		_, ok := d.cache.get(lookupCapability, name)
+
+		return !ok
+	})
+
+	if uncached {
+		nodes, err := d.graphql.listNodes(ctx, "capabilities", "id name", nil)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving capabilities IDs: %w", err)
+		}
+
+		capabilities, err := decodeNodes[capabilityNode](nodes)
+		if err != nil {
+			return nil, fmt.Errorf("decoding capabilities: %w", err)
+		}
+
+		// The IDs are cached by the name returned along with them
+		for _, capability := range capabilities {
+			d.cache.set(lookupCapability, capability.Name, capability.ID)
+		}
+	}
+
+	ids := make([]string, 0, len(names))
+
+	for _, name := range names {
+		id, ok := d.cache.get(lookupCapability, name)
+		if !ok {
+			return nil, fmt.Errorf("unknown capability %s", name)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &capabilitiesDataSource{}
+	_ datasource.DataSourceWithConfigure      = &capabilitiesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &capabilitiesDataSource{}
+)
+
+// NewCapabilitiesDataSource is a helper function to simplify the provider implementation.
+func NewCapabilitiesDataSource() datasource.DataSource {
+	return &capabilitiesDataSource{}
+}
+
+// capabilitiesDataSource is the data source implementation.
+type capabilitiesDataSource struct {
+	data *openctiProviderData
+}
+
+// capabilitiesDataSourceModel maps the data source schema data.
+type capabilitiesDataSourceModel struct {
+	Capabilities []capabilityModel `tfsdk:"capabilities"`
+}
+
+// capabilityModel maps a capability.
+type capabilityModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Ordering    types.Int64  `tfsdk:"ordering"`
+	Parent      types.String `tfsdk:"parent"`
+}
+
+// capabilityNode is a capability as returned by opencti.
+type capabilityNode struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	AttributeOrder int64  `json:"attribute_order"`
+}
+
+// capabilitySeparator separates the name of a capability from the names of its children,
+// e.g. KNOWLEDGE and KNOWLEDGE_KNUPDATE.
+const capabilitySeparator = "_"
+
+// capabilitiesRequirement is the minimum platform supporting the capability listing.
+var capabilitiesRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
+}
+
+// Metadata returns the data source type name.
+func (d *capabilitiesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_capabilities"
+}
+
+// Schema defines the schema for the data source.
+func (d *capabilitiesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the capabilities of the platform, whose names are accepted by the `capabilities` of the `opencti_role` resource.",
+		Attributes: map[string]schema.Attribute{
+			"capabilities": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Capabilities sorted by ordering and name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"ordering": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Position of the capability in the opencti interface.",
+						},
+						"parent": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the parent capability, e.g. `KNOWLEDGE` for `KNOWLEDGE_KNUPDATE`, null for the top-level capabilities.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *capabilitiesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	nodes, err := d.data.graphql.listNodes(ctx, "capabilities", "id name description attribute_order", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing opencti capabilities",
+			"Could not list capabilities, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	capabilities, err := decodeNodes[capabilityNode](nodes)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error decoding opencti capabilities",
+			"Could not decode capabilities, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	names := map[string]bool{}
+
+	for _, capability := range capabilities {
+		names[capability.Name] = true
+
+		// The roles resolve the capabilities by name
+		d.data.cache.set(lookupCapability, capability.Name, capability.ID)
+	}
+
+	state := capabilitiesDataSourceModel{
+		Capabilities: []capabilityModel{},
+	}
+
+	for _, capability := range capabilities {
+		state.Capabilities = append(state.Capabilities, capabilityModel{
+			ID:          types.StringValue(capability.ID),
+			Name:        types.StringValue(capability.Name),
+			Description: types.StringValue(capability.Description),
+			Ordering:    types.Int64Value(capability.AttributeOrder),
+			Parent:      capabilityParent(capability.Name, names),
+		})
+	}
+
+	sort.Slice(state.Capabilities, func(i, j int) bool {
+		if state.Capabilities[i].Ordering.ValueInt64() != state.Capabilities[j].Ordering.ValueInt64() {
+			return state.Capabilities[i].Ordering.ValueInt64() < state.Capabilities[j].Ordering.ValueInt64()
+		}
+
+		return state.Capabilities[i].Name.ValueString() < state.Capabilities[j].Name.ValueString()
+	})
+
+	tflog.Debug(ctx, fmt.Sprintf("Capabilities found: %d", len(state.Capabilities)))
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *capabilitiesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*openctiProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+// ValidateConfig checks that the platform supports the data source.
+func (d *capabilitiesDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if d.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.data.platform.check(capabilitiesRequirement, "opencti_capabilities")...)
+}
+
+// capabilityParent returns the name of the closest capability whose name prefixes the given one.
+func capabilityParent(name string, names map[string]bool) types.String {
+	for i := strings.LastIndex(name, capabilitySeparator); i > 0; i = strings.LastIndex(name[:i], capabilitySeparator) {
+		if names[name[:i]] {
+			return types.StringValue(name[:i])
+		}
+	}
+
+	return types.StringNull()
+}
@@ -13,15 +13,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/weisshorn-cyd/gocti"
 	"github.com/weisshorn-cyd/gocti/system"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &caseTemplateResource{}
-	_ resource.ResourceWithConfigure   = &caseTemplateResource{}
-	_ resource.ResourceWithImportState = &caseTemplateResource{}
+	_ resource.Resource                   = &caseTemplateResource{}
+	_ resource.ResourceWithConfigure      = &caseTemplateResource{}
+	_ resource.ResourceWithImportState    = &caseTemplateResource{}
+	_ resource.ResourceWithModifyPlan     = &caseTemplateResource{}
+	_ resource.ResourceWithValidateConfig = &caseTemplateResource{}
 )
 
 // NewCaseTemplateResource is a helper function to simplify the provider implementation.
@@ -31,16 +32,23 @@ func NewCaseTemplateResource() resource.Resource {
 
 // caseTemplateResource is the resource implementation.
 type caseTemplateResource struct {
-	client *gocti.OpenCTIAPIClient
+	data *openctiProviderData
 }
 
 // caseTemplateResourceModel maps the resource schema data.
 type caseTemplateResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Tasks       types.Set    `tfsdk:"tasks"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	Tasks           types.Set    `tfsdk:"tasks"`
+	ImpersonateUser types.String `tfsdk:"impersonate_user"`
+	Timeouts        types.Object `tfsdk:"timeouts"`
+	LastUpdated     types.String `tfsdk:"last_updated"`
+}
+
+// caseTemplateRequirement is the minimum platform supporting the case templates.
+var caseTemplateRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
 }
 
 // Metadata returns the resource type name.
@@ -80,12 +88,19 @@ func (r *caseTemplateResource) Schema(_ context.Context, _ resource.SchemaReques
 					setplanmodifier.RequiresReplace(),
 				},
 			},
+			"impersonate_user": impersonateUserAttribute(),
+			"timeouts":         timeoutsAttribute(),
 		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *caseTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "create", "opencti_case_template") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan caseTemplateResourceModel
 
@@ -96,6 +111,16 @@ func (r *caseTemplateResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "create", "opencti_case_template")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, "Creating case templates")
 
 	// Convert the tasks ListValue to a []string
@@ -108,9 +133,9 @@ func (r *caseTemplateResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	// Create new case template
-	createdCase, err := r.client.CreateCaseTemplate(ctx, "id name description tasks { edges { node { id name } } }", system.CaseTemplateAddInput{
-		Name:        plan.Name.ValueString(),
-		Description: plan.Description.ValueString(),
+	createdCase, err := r.data.client.CreateCaseTemplate(ctx, "id name description tasks { edges { node { id name } } }", system.CaseTemplateAddInput{
+		Name:        r.data.managedName(plan.Name.ValueString()),
+		Description: r.data.markDescription(plan.Description.ValueString()),
 		Tasks:       taskList,
 	})
 	if err != nil {
@@ -133,8 +158,8 @@ func (r *caseTemplateResource) Create(ctx context.Context, req resource.CreateRe
 	resp.Diagnostics.Append(diags...)
 
 	plan.ID = types.StringValue(createdCase.ID)
-	plan.Name = types.StringValue(createdCase.Name)
-	plan.Description = types.StringValue(createdCase.Description)
+	plan.Name = types.StringValue(r.data.stateName(createdCase.Name))
+	plan.Description = types.StringValue(r.data.unmarkDescription(createdCase.Description))
 	plan.Tasks = tasksList
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
@@ -159,8 +184,12 @@ func (r *caseTemplateResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "read", "opencti_case_template")
+
 	// Read case template from opencti
-	caseTemplate, err := r.client.ReadCaseTemplate(ctx, "id name description tasks { edges { node { id name } } }", state.ID.ValueString())
+	caseTemplate, err := r.data.client.ReadCaseTemplate(ctx, "id name description tasks { edges { node { id name } } }", state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading opencti case template", err.Error(),
@@ -180,8 +209,8 @@ func (r *caseTemplateResource) Read(ctx context.Context, req resource.ReadReques
 	resp.Diagnostics.Append(diags...)
 
 	state.ID = types.StringValue(caseTemplate.ID)
-	state.Name = types.StringValue(caseTemplate.Name)
-	state.Description = types.StringValue(caseTemplate.Description)
+	state.Name = types.StringValue(r.data.stateName(caseTemplate.Name))
+	state.Description = types.StringValue(r.data.unmarkDescription(caseTemplate.Description))
 	state.Tasks = tasksList
 
 	// Set refreshed state
@@ -195,10 +224,70 @@ func (r *caseTemplateResource) Read(ctx context.Context, req resource.ReadReques
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *caseTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "update", "opencti_case_template") {
+		return
+	}
+
+	// Only the timeouts can be updated in place, keep the current state with the new timeouts
+	var plan, state caseTemplateResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "update", "opencti_case_template")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	caseTemplate, err := r.data.client.ReadCaseTemplate(ctx, "id description", state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading opencti case template", err.Error(),
+		)
+
+		return
+	}
+
+	// Stamp a case template created before the ownership marker was set
+	if err := r.data.restamp(ctx, "opencti_case_template", state.ID.ValueString(), "description", caseTemplate.Description, r.data.markDescription(state.Description.ValueString())); err != nil {
+		resp.Diagnostics.AddError(
+			"Error stamping case template", err.Error(),
+		)
+
+		return
+	}
+
+	state.Timeouts = plan.Timeouts
+	state.ImpersonateUser = plan.ImpersonateUser
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *caseTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "delete", "opencti_case_template") {
+		return
+	}
+
 	// Retrieve values from state
 	var state caseTemplateResourceModel
 
@@ -209,7 +298,17 @@ func (r *caseTemplateResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	if _, err := r.client.DeleteCaseTemplate(ctx, state.ID.ValueString()); err != nil {
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "delete", "opencti_case_template")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, state.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.data.client.DeleteCaseTemplate(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting OpenCTI Case Template",
 			"Could not delete case template, unexpected error: "+err.Error(),
@@ -227,17 +326,37 @@ func (r *caseTemplateResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*gocti.OpenCTIAPIClient)
+	data, ok := req.ProviderData.(*openctiProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *gocti.OpenCTIAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.data = data
+}
+
+// ValidateConfig checks that the platform supports the resource.
+func (r *caseTemplateResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.platform.check(caseTemplateRequirement, "opencti_case_template")...)
+}
+
+// ModifyPlan checks that the provider allows the planned change.
+func (r *caseTemplateResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the provider is not configured, e.g. when its configuration is deferred
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.checkReadOnlyPlan(req.State, resp.Plan, "opencti_case_template")...)
 }
 
 func (r *caseTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// resourceDefaults are the default attribute values of the resources, set in the provider configuration.
+type resourceDefaults struct {
+	User  userDefaultsModel
+	Group groupDefaultsModel
+}
+
+// defaultsModel maps the defaults block of the provider configuration.
+type defaultsModel struct {
+	User  types.Object `tfsdk:"user"`
+	Group types.Object `tfsdk:"group"`
+}
+
+// userDefaultsModel maps the defaults of the opencti_user resources.
+type userDefaultsModel struct {
+	UserConfidenceLevel types.Object `tfsdk:"user_confidence_level"`
+}
+
+// groupDefaultsModel maps the defaults of the opencti_group resources.
+type groupDefaultsModel struct {
+	MaxConfidenceLevel types.Int32 `tfsdk:"max_confidence_level"`
+	AutoNewMarking     types.Bool  `tfsdk:"auto_new_marking"`
+	DefaultAssignation types.Bool  `tfsdk:"default_assignation"`
+}
+
+// defaultsAttribute returns the schema of the defaults block of the provider.
+func defaultsAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "Default values of the resource attributes, used when the attributes are not set on the resources. The `defaulted_attributes` attribute of the resources lists the attributes whose value comes from these defaults.",
+		Attributes: map[string]schema.Attribute{
+			"user": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Defaults of the `opencti_user` resources.",
+				Attributes: map[string]schema.Attribute{
+					"user_confidence_level": schema.SingleNestedAttribute{
+						Optional:            true,
+						MarkdownDescription: "Default user confidence configuration.",
+						Attributes: map[string]schema.Attribute{
+							"max_confidence": schema.Int64Attribute{
+								Required: true,
+							},
+							"overrides": schema.ListNestedAttribute{
+								Optional: true,
+								NestedObject: schema.NestedAttributeObject{
+									Attributes: map[string]schema.Attribute{
+										"entity_type": schema.StringAttribute{
+											Required: true,
+										},
+										"max_confidence": schema.Int64Attribute{
+											Required: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"group": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Defaults of the `opencti_group` resources.",
+				Attributes: map[string]schema.Attribute{
+					"max_confidence_level": schema.Int32Attribute{
+						Optional: true,
+					},
+					"auto_new_marking": schema.BoolAttribute{
+						Optional: true,
+					},
+					"default_assignation": schema.BoolAttribute{
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// parseDefaults reads the defaults block of the provider configuration.
+func parseDefaults(ctx context.Context, value types.Object) (resourceDefaults, diag.Diagnostics) {
+	var (
+		defaults resourceDefaults
+		diags    diag.Diagnostics
+	)
+
+	if value.IsNull() || value.IsUnknown() {
+		return defaults, diags
+	}
+
+	var model defaultsModel
+
+	diags.Append(value.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+
+	if !model.User.IsNull() {
+		diags.Append(model.User.As(ctx, &defaults.User, basetypes.ObjectAsOptions{})...)
+	}
+
+	if !model.Group.IsNull() {
+		diags.Append(model.Group.As(ctx, &defaults.Group, basetypes.ObjectAsOptions{})...)
+	}
+
+	// The confidence level read back from opencti always has a list of overrides
+	if level := defaults.User.UserConfidenceLevel; !level.IsNull() && !level.IsUnknown() {
+		attributes := level.Attributes()
+
+		if overrides, ok := attributes["overrides"].(types.List); ok && overrides.IsNull() {
+			overrideType, _ := overrides.ElementType(ctx).(types.ObjectType)
+			attributes["overrides"] = types.ListValueMust(overrideType, []attr.Value{})
+
+			defaults.User.UserConfidenceLevel = types.ObjectValueMust(level.AttributeTypes(ctx), attributes)
+		}
+	}
+
+	return defaults, diags
+}
+
+// applyDefault sets an attribute of the plan to its provider default when it is not configured,
+// and records it in defaulted.
+func applyDefault[T attr.Value](ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attribute string, value T, defaulted *[]string) {
+	var config T
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &config)...)
+
+	if !config.IsNull() || value.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), value)...)
+	*defaulted = append(*defaulted, attribute)
+}
+
+// setDefaultedAttributes records in the plan the attributes whose value comes from the provider defaults.
+func setDefaultedAttributes(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, defaulted []string) {
+	// The resources created before the defaults were introduced have no defaulted attributes in
+	// their state, keep it as is so that an unchanged configuration does not plan an update
+	if len(defaulted) == 0 && !req.State.Raw.IsNull() {
+		var state types.List
+
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("defaulted_attributes"), &state)...)
+
+		if state.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("defaulted_attributes"), state)...)
+
+			return
+		}
+	}
+
+	sort.Strings(defaulted)
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("defaulted_attributes"), defaulted)...)
+}
+
+// configuredOrDefaulted tells whether an attribute is configured or has a provider default.
+func configuredOrDefaulted[T attr.Value](ctx context.Context, diags *diag.Diagnostics, config tfsdk.Config, attribute string, value T) bool {
+	var configured T
+
+	diags.Append(config.GetAttribute(ctx, path.Root(attribute), &configured)...)
+
+	return !configured.IsNull() || !value.IsNull()
+}
+
+// applyUserDefaults merges the provider defaults into the plan of an opencti_user.
+func (d *openctiProviderData) applyUserDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defaulted := []string{}
+
+	// Without any default, the user confidence level is set on creation
+	applyDefault(ctx, req, resp, "user_confidence_level", d.defaults.User.UserConfidenceLevel, &defaulted)
+
+	// The user confidence level cannot be updated in place, a new default replaces the users
+	if len(defaulted) > 0 && !req.State.Raw.IsNull() {
+		var state types.Object
+
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("user_confidence_level"), &state)...)
+
+		if !state.Equal(d.defaults.User.UserConfidenceLevel) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("user_confidence_level"))
+		}
+	}
+
+	setDefaultedAttributes(ctx, req, resp, defaulted)
+}
+
+// applyGroupDefaults merges the provider defaults into the plan of an opencti_group.
+func (d *openctiProviderData) applyGroupDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defaulted := []string{}
+
+	applyDefault(ctx, req, resp, "max_confidence_level", d.defaults.Group.MaxConfidenceLevel, &defaulted)
+	applyDefault(ctx, req, resp, "auto_new_marking", d.defaults.Group.AutoNewMarking, &defaulted)
+	applyDefault(ctx, req, resp, "default_assignation", d.defaults.Group.DefaultAssignation, &defaulted)
+
+	setDefaultedAttributes(ctx, req, resp, defaulted)
+}
+
+// validateGroupDefaults checks that the attributes of an opencti_group without provider defaults are configured.
+func (d *openctiProviderData) validateGroupDefaults(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var (
+		diags   diag.Diagnostics
+		missing []string
+	)
+
+	if !configuredOrDefaulted(ctx, &diags, config, "max_confidence_level", d.defaults.Group.MaxConfidenceLevel) {
+		missing = append(missing, "max_confidence_level")
+	}
+
+	if !configuredOrDefaulted(ctx, &diags, config, "auto_new_marking", d.defaults.Group.AutoNewMarking) {
+		missing = append(missing, "auto_new_marking")
+	}
+
+	if !configuredOrDefaulted(ctx, &diags, config, "default_assignation", d.defaults.Group.DefaultAssignation) {
+		missing = append(missing, "default_assignation")
+	}
+
+	for _, attribute := range missing {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Missing opencti group attribute",
+			fmt.Sprintf("The %s attribute must be set, either on the resource or in the group defaults of the provider.", attribute),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestApplyGroupDefaults(t *testing.T) {
+	t.Parallel()
+
+	var schemaResp resource.SchemaResponse
+
+	NewGroupResource().Schema(t.Context(), resource.SchemaRequest{}, &schemaResp)
+
+	timeoutsType, _ := schemaResp.Schema.Attributes["timeouts"].GetType().(types.ObjectType)
+
+	// group returns a group model with the given attributes and the others null
+	group := func(maxConfidence types.Int32, autoNewMarking, defaultAssignation types.Bool, defaulted types.List) groupResourceModel {
+		return groupResourceModel{
+			Name:                types.StringValue("SOC"),
+			Roles:               types.ListNull(types.StringType),
+			AllowedMarking:      types.ListNull(types.StringType),
+			MaxConfidenceLevel:  maxConfidence,
+			AutoNewMarking:      autoNewMarking,
+			DefaultAssignation:  defaultAssignation,
+			DefaultedAttributes: defaulted,
+			Timeouts:            types.ObjectNull(timeoutsType.AttrTypes),
+		}
+	}
+
+	defaulted := func(attributes ...string) types.List {
+		values := []attr.Value{}
+		for _, attribute := range attributes {
+			values = append(values, types.StringValue(attribute))
+		}
+
+		return types.ListValueMust(types.StringType, values)
+	}
+
+	noDefaults := groupDefaultsModel{
+		MaxConfidenceLevel: types.Int32Null(),
+		AutoNewMarking:     types.BoolNull(),
+		DefaultAssignation: types.BoolNull(),
+	}
+
+	allDefaults := groupDefaultsModel{
+		MaxConfidenceLevel: types.Int32Value(50),
+		AutoNewMarking:     types.BoolValue(true),
+		DefaultAssignation: types.BoolValue(false),
+	}
+
+	tests := []struct {
+		name     string
+		defaults groupDefaultsModel
+		config   groupResourceModel
+		state    *groupResourceModel
+		want     groupResourceModel
+	}{
+		{
+			name:     "configured without defaults",
+			defaults: noDefaults,
+			config:   group(types.Int32Value(80), types.BoolValue(false), types.BoolValue(true), types.ListUnknown(types.StringType)),
+			want:     group(types.Int32Value(80), types.BoolValue(false), types.BoolValue(true), defaulted()),
+		},
+		{
+			name:     "all defaulted",
+			defaults: allDefaults,
+			config:   group(types.Int32Null(), types.BoolNull(), types.BoolNull(), types.ListUnknown(types.StringType)),
+			want:     group(types.Int32Value(50), types.BoolValue(true), types.BoolValue(false), defaulted("auto_new_marking", "default_assignation", "max_confidence_level")),
+		},
+		{
+			name:     "configured over defaults",
+			defaults: allDefaults,
+			config:   group(types.Int32Value(80), types.BoolNull(), types.BoolValue(true), types.ListUnknown(types.StringType)),
+			want:     group(types.Int32Value(80), types.BoolValue(true), types.BoolValue(true), defaulted("auto_new_marking")),
+		},
+		{
+			name: "partial defaults",
+			defaults: groupDefaultsModel{
+				MaxConfidenceLevel: types.Int32Value(50),
+				AutoNewMarking:     types.BoolNull(),
+				DefaultAssignation: types.BoolNull(),
+			},
+			config: group(types.Int32Null(), types.BoolValue(false), types.BoolValue(false), types.ListUnknown(types.StringType)),
+			want:   group(types.Int32Value(50), types.BoolValue(false), types.BoolValue(false), defaulted("max_confidence_level")),
+		},
+		{
+			name:     "state without defaulted attributes",
+			defaults: noDefaults,
+			config:   group(types.Int32Value(80), types.BoolValue(false), types.BoolValue(true), types.ListUnknown(types.StringType)),
+			state:    ptr(group(types.Int32Value(80), types.BoolValue(false), types.BoolValue(true), types.ListNull(types.StringType))),
+			want:     group(types.Int32Value(80), types.BoolValue(false), types.BoolValue(true), types.ListNull(types.StringType)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			if diags := plan.Set(ctx, tt.config); diags.HasError() {
+				t.Fatalf("setting the plan: %v", diags.Errors())
+			}
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			if tt.state != nil {
+				if diags := state.Set(ctx, tt.state); diags.HasError() {
+					t.Fatalf("setting the state: %v", diags.Errors())
+				}
+			}
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw},
+				Plan:   plan,
+				State:  state,
+			}
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+
+			data := &openctiProviderData{defaults: resourceDefaults{Group: tt.defaults}}
+			data.applyGroupDefaults(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("applyGroupDefaults returned errors: %v", resp.Diagnostics.Errors())
+			}
+
+			var got groupResourceModel
+			if diags := resp.Plan.Get(ctx, &got); diags.HasError() {
+				t.Fatalf("reading the plan: %v", diags.Errors())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applyGroupDefaults() plan = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// ptr returns a pointer to a copy of the value.
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// graphqlClient sends raw GraphQL requests to opencti, for the operations which are
+// not covered by gocti. It shares the HTTP transport of the gocti client.
+type graphqlClient struct {
+	url        string
+	token      string
+	httpClient *http.Client
+	// logger receives the requests, as the gocti logs, when set
+	logger *slog.Logger
+}
+
+// graphqlRequest is the body of a GraphQL request.
+type graphqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// graphqlResponse is the body of a GraphQL response.
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Do sends a query with its variables and decodes the data of the response into data.
+func (c *graphqlClient) Do(ctx context.Context, query string, variables map[string]any, data any) error {
+	body, err := json.Marshal(graphqlRequest{Query: query, Variables: variables})
+	if err != nil {
+		return fmt.Errorf("encoding graphql request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.url, "/")+"/graphql", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating graphql request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if c.logger != nil {
+		c.logger.DebugContext(ctx, "Sending graphql request", "query", query, "variables", variables)
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending graphql request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading graphql response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("graphql request failed with status %s: %s", resp.Status, string(respBody))
+	}
+
+	var gqlResp graphqlResponse
+	if err := json.Unmarshal(respBody, &gqlResp); err != nil {
+		return fmt.Errorf("decoding graphql response: %w", err)
+	}
+
+	if len(gqlResp.Errors) > 0 {
+		errs := make([]error, 0, len(gqlResp.Errors))
+		for _, gqlErr := range gqlResp.Errors {
+			errs = append(errs, errors.New(gqlErr.Message))
+		}
+
+		return fmt.Errorf("graphql request returned errors: %w", errors.Join(errs...))
+	}
+
+	if data == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(gqlResp.Data, data); err != nil {
+		return fmt.Errorf("decoding graphql data: %w", err)
+	}
+
+	return nil
+}
+
+// eqFilter returns an opencti filter group matching the objects whose key equals the value.
+func eqFilter(key, value string) map[string]any {
+	return andFilters(newFilter(key, "eq", value))
+}
+
+// newFilter returns an opencti filter comparing the key with any of the values.
+func newFilter(key, operator string, values ...string) map[string]any {
+	return map[string]any{
+		"key":      []string{key},
+		"values":   values,
+		"operator": operator,
+		"mode":     "or",
+	}
+}
+
+// andFilters returns an opencti filter group matching the objects which match all the filters.
+func andFilters(filters ...map[string]any) map[string]any {
+	return map[string]any{
+		"mode":         "and",
+		"filters":      filters,
+		"filterGroups": []any{},
+	}
+}
+
+// listPageSize is the number of objects requested per page when listing objects.
+const listPageSize = 500
+
+// listNodes returns the nodes of all the pages of a connection field, such as groups,
+// with the given attributes, matching the filter group when not nil. The nodes are left
+// encoded for the caller to decode.
+func (c *graphqlClient) listNodes(ctx context.Context, field, attributes string, filters map[string]any) ([]json.RawMessage, error) {
+	query := fmt.Sprintf("query List($first: Int, $after: ID) { %s(first: $first, after: $after) { edges { node { %s } } pageInfo { endCursor hasNextPage } } }", field, attributes)
+
+	nodes := []json.RawMessage{}
+	variables := map[string]any{"first": listPageSize}
+
+	if filters != nil {
+		query = fmt.Sprintf("query List($first: Int, $after: ID, $filters: FilterGroup) { %s(first: $first, after: $after, filters: $filters) { edges { node { %s } } pageInfo { endCursor hasNextPage } } }", field, attributes)
+		variables["filters"] = filters
+	}
+
+	for {
+		var data map[string]struct {
+			Edges []struct {
+				Node json.RawMessage `json:"node"`
+			} `json:"edges"`
+			PageInfo struct {
+				EndCursor   string `json:"endCursor"`
+				HasNextPage bool   `json:"hasNextPage"`
+			} `json:"pageInfo"`
+		}
+
+		if err := c.Do(ctx, query, variables, &data); err != nil {
+			return nil, fmt.Errorf("listing %s: %w", field, err)
+		}
+
+		for _, edge := range data[field].Edges {
+			nodes = append(nodes, edge.Node)
+		}
+
+		if !data[field].PageInfo.HasNextPage {
+			return nodes, nil
+		}
+
+		variables["after"] = data[field].PageInfo.EndCursor
+	}
+}
+
+// decodeNodes decodes the nodes returned by listNodes.
+func decodeNodes[T any](nodes []json.RawMessage) ([]T, error) {
+	decoded := make([]T, 0, len(nodes))
+
+	for _, raw := range nodes {
+		var node T
+		if err := json.Unmarshal(raw, &node); err != nil {
+			return nil, err
+		}
+
+		decoded = append(decoded, node)
+	}
+
+	return decoded, nil
+}
+
+// graphqlOperationTypes returns the types of the operations of a GraphQL document, such as
+// query or mutation, in their order. A shorthand { ... } operation is a query.
+func graphqlOperationTypes(document string) []string {
+	var (
+		operations  []string
+		braces      int
+		parentheses int
+		inHeader    bool
+	)
+
+	for i := 0; i < len(document); i++ {
+		c := document[i]
+
+		switch {
+		case c == '#':
+			// Comments run to the end of the line
+			for i < len(document) && document[i] != '\n' {
+				i++
+			}
+		case c == '"':
+			// Strings and block strings cannot contain the delimiters of the operations
+			i = skipGraphQLString(document, i)
+		case c == '(':
+			parentheses++
+		case c == ')':
+			parentheses--
+		case parentheses > 0:
+		case c == '{':
+			if braces == 0 && !inHeader {
+				operations = append(operations, "query")
+			}
+
+			inHeader = false
+			braces++
+		case c == '}':
+			braces--
+		case braces == 0 && isJSONPathIdentifier(c, true):
+			end := i + 1
+			for end < len(document) && isJSONPathIdentifier(document[end], false) {
+				end++
+			}
+
+			if !inHeader {
+				switch word := document[i:end]; word {
+				case "query", "mutation", "subscription":
+					operations = append(operations, word)
+				}
+
+				inHeader = true
+			}
+
+			i = end - 1
+		}
+	}
+
+	return operations
+}
+
+// skipGraphQLString returns the position of the end of the string starting at pos.
+func skipGraphQLString(document string, pos int) int {
+	if strings.HasPrefix(document[pos:], `"""`) {
+		end := strings.Index(document[pos+3:], `"""`)
+		if end < 0 {
+			return len(document)
+		}
+
+		return pos + 3 + end + 2
+	}
+
+	for i := pos + 1; i < len(document); i++ {
+		switch document[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return len(document)
+}
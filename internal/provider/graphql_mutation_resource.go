@@ -0,0 +1,598 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &graphqlMutationResource{}
+	_ resource.ResourceWithConfigure      = &graphqlMutationResource{}
+	_ resource.ResourceWithModifyPlan     = &graphqlMutationResource{}
+	_ resource.ResourceWithValidateConfig = &graphqlMutationResource{}
+)
+
+// appliedResultKey is the private state key of the read result stored by the last create or update,
+// which the refreshed result is compared with to detect drift.
+const appliedResultKey = "applied_result"
+
+// NewGraphQLMutationResource is a helper function to simplify the provider implementation.
+func NewGraphQLMutationResource() resource.Resource {
+	return &graphqlMutationResource{}
+}
+
+// graphqlMutationResource is the resource implementation.
+type graphqlMutationResource struct {
+	data *openctiProviderData
+}
+
+// graphqlMutationResourceModel maps the resource schema data.
+type graphqlMutationResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Create          types.String `tfsdk:"create"`
+	Read            types.String `tfsdk:"read"`
+	Update          types.String `tfsdk:"update"`
+	Delete          types.String `tfsdk:"delete"`
+	Variables       types.String `tfsdk:"variables"`
+	IDPath          types.String `tfsdk:"id_path"`
+	ResultPath      types.String `tfsdk:"result_path"`
+	Result          types.String `tfsdk:"result"`
+	ImpersonateUser types.String `tfsdk:"impersonate_user"`
+	Timeouts        types.Object `tfsdk:"timeouts"`
+	LastUpdated     types.String `tfsdk:"last_updated"`
+}
+
+// graphqlMutationRequirement is the minimum platform supporting the GraphQL documents of the provider.
+var graphqlMutationRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
+}
+
+// Metadata returns the resource type name.
+func (r *graphqlMutationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_graphql_mutation"
+}
+
+// Schema defines the schema for the resource.
+func (r *graphqlMutationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an opencti object which is not modelled by the provider with GraphQL documents. " +
+			"The read, update and delete documents receive the `variables` along with the ID of the object as the `$id` variable. " +
+			"A change of the read result outside of Terraform is planned as an update, or as a replacement without `update` document. " +
+			"The resource cannot be imported, as the GraphQL documents are not known from the ID of an object.",
+		Attributes: map[string]schema.Attribute{
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "GraphQL mutation creating the object.",
+			},
+			"read": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "GraphQL query reading the object. The object is considered deleted when the result, or the value at `result_path`, is null.",
+			},
+			"update": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "GraphQL mutation updating the object. Without it, a change of `variables` replaces the object.",
+			},
+			"delete": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "GraphQL mutation deleting the object.",
+			},
+			"variables": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "JSON encoded object of the variables of the documents, e.g. with `jsonencode()`.",
+			},
+			"id_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "JMESPath-style expression of the ID of the created object in the result of the `create` mutation, e.g. `groupAdd.id`. See the `extract` attribute of the `opencti_graphql_query` data source for the supported expressions.",
+			},
+			"result_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "JMESPath-style expression selecting the part of the read result kept in `result`, e.g. to leave out the fields changing on their own such as `updated_at`.",
+			},
+			"result": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "JSON encoded result of the `read` query, or its value at `result_path`.",
+			},
+			"impersonate_user": impersonateUserAttribute(),
+			"timeouts":         timeoutsAttribute(),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *graphqlMutationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "create", "opencti_graphql_mutation") {
+		return
+	}
+
+	// Retrieve values from plan
+	var plan graphqlMutationResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "create", "opencti_graphql_mutation")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	variables := parseGraphQLVariables(&resp.Diagnostics, path.Root("variables"), plan.Variables)
+
+	idPath, err := compileJSONPath(plan.IDPath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("id_path"), "Invalid id_path expression", err.Error())
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Creating object with a GraphQL mutation")
+
+	var data json.RawMessage
+
+	if err := r.data.graphql.Do(ctx, plan.Create.ValueString(), variables, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating object",
+			"Could not run the create mutation, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	decoded, err := decodeJSON(data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error decoding opencti GraphQL result",
+			"Could not decode the result of the create mutation, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	var id string
+
+	switch v := idPath.search(decoded).(type) {
+	case string:
+		id = v
+	case json.Number:
+		id = v.String()
+	}
+
+	if id == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id_path"),
+			"Missing created object ID",
+			"The result of the create mutation has no ID at "+plan.IDPath.ValueString()+": "+string(data),
+		)
+
+		return
+	}
+
+	tflog.Info(ctx, "Object created", map[string]any{"id": id})
+
+	// Save the ID first so that a failing read does not leave the object unmanaged
+	plan.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+
+	result, found := r.readResult(ctx, &resp.Diagnostics, plan, variables)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error reading created object",
+			"The read query did not return the created object "+id+".",
+		)
+
+		return
+	}
+
+	plan.Result = types.StringValue(result)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, appliedResultKey, []byte(result))...)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *graphqlMutationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state graphqlMutationResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "read", "opencti_graphql_mutation")
+
+	variables := parseGraphQLVariables(&resp.Diagnostics, path.Root("variables"), state.Variables)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, found := r.readResult(ctx, &resp.Diagnostics, state, variables)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The object was deleted outside of Terraform
+	if !found {
+		tflog.Info(ctx, "Object not found, removing it from the state", map[string]any{"id": state.ID.ValueString()})
+
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	tflog.Info(ctx, "Object read", map[string]any{"id": state.ID.ValueString()})
+
+	// An imported object has no applied result yet, its current result becomes the reference
+	applied, diags := req.Private.GetKey(ctx, appliedResultKey)
+	resp.Diagnostics.Append(diags...)
+
+	if applied == nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, appliedResultKey, []byte(result))...)
+	}
+
+	state.Result = types.StringValue(result)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *graphqlMutationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "update", "opencti_graphql_mutation") {
+		return
+	}
+
+	// Retrieve values from plan
+	var plan graphqlMutationResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "update", "opencti_graphql_mutation")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	variables := parseGraphQLVariables(&resp.Diagnostics, path.Root("variables"), plan.Variables)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without update mutation, only the documents changed and the result is read again
+	if !plan.Update.IsNull() {
+		tflog.Info(ctx, "Updating object with a GraphQL mutation", map[string]any{"id": plan.ID.ValueString()})
+
+		if err := r.data.graphql.Do(ctx, plan.Update.ValueString(), withObjectID(variables, plan.ID.ValueString()), nil); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating object",
+				"Could not run the update mutation, unexpected error: "+err.Error(),
+			)
+
+			return
+		}
+	}
+
+	result, found := r.readResult(ctx, &resp.Diagnostics, plan, variables)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error reading updated object",
+			"The read query did not return the updated object "+plan.ID.ValueString()+".",
+		)
+
+		return
+	}
+
+	plan.Result = types.StringValue(result)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, appliedResultKey, []byte(result))...)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *graphqlMutationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "delete", "opencti_graphql_mutation") {
+		return
+	}
+
+	// Retrieve values from state
+	var state graphqlMutationResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "delete", "opencti_graphql_mutation")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, state.ImpersonateUser)
+
+	variables := parseGraphQLVariables(&resp.Diagnostics, path.Root("variables"), state.Variables)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.data.graphql.Do(ctx, state.Delete.ValueString(), withObjectID(variables, state.ID.ValueString()), nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting OpenCTI Object",
+			"Could not run the delete mutation, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *graphqlMutationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*openctiProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.data = data
+}
+
+// ValidateConfig checks that the platform supports the resource.
+func (r *graphqlMutationResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.platform.check(graphqlMutationRequirement, "opencti_graphql_mutation")...)
+}
+
+// ModifyPlan checks the documents and plans an update, or a replacement, when the read result drifted.
+func (r *graphqlMutationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the provider is not configured, e.g. when its configuration is deferred
+	if r.data == nil {
+		return
+	}
+
+	// Nothing else to check when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(r.data.checkReadOnlyPlan(req.State, resp.Plan, "opencti_graphql_mutation")...)
+
+		return
+	}
+
+	var plan graphqlMutationResourceModel
+
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkGraphQLDocument(&resp.Diagnostics, path.Root("create"), plan.Create, "mutation")
+	checkGraphQLDocument(&resp.Diagnostics, path.Root("read"), plan.Read, "query")
+	checkGraphQLDocument(&resp.Diagnostics, path.Root("update"), plan.Update, "mutation")
+	checkGraphQLDocument(&resp.Diagnostics, path.Root("delete"), plan.Delete, "mutation")
+
+	for _, attribute := range []struct {
+		name  string
+		value types.String
+	}{
+		{"id_path", plan.IDPath},
+		{"result_path", plan.ResultPath},
+	} {
+		if attribute.value.IsNull() || attribute.value.IsUnknown() {
+			continue
+		}
+
+		if _, err := compileJSONPath(attribute.value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute.name), "Invalid "+attribute.name+" expression", err.Error())
+		}
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state graphqlMutationResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		// Without update mutation, the object is replaced when its variables change
+		if plan.Update.IsNull() && !plan.Variables.Equal(state.Variables) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("variables"))
+		}
+
+		applied, diags := req.Private.GetKey(ctx, appliedResultKey)
+		resp.Diagnostics.Append(diags...)
+
+		// The read result changed outside of Terraform
+		if applied != nil && string(applied) != state.Result.ValueString() {
+			tflog.Info(ctx, "Object drifted from the last applied result", map[string]any{"id": state.ID.ValueString()})
+
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("result"), types.StringUnknown())...)
+
+			if plan.Update.IsNull() {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("result"))
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(r.data.checkReadOnlyPlan(req.State, resp.Plan, "opencti_graphql_mutation")...)
+}
+
+// readResult runs the read query of the object and returns its normalized result, or false when
+// the object is not found.
+func (r *graphqlMutationResource) readResult(ctx context.Context, diags *diag.Diagnostics, model graphqlMutationResourceModel, variables map[string]any) (string, bool) {
+	var data json.RawMessage
+
+	if err := r.data.graphql.Do(ctx, model.Read.ValueString(), withObjectID(variables, model.ID.ValueString()), &data); err != nil {
+		diags.AddError(
+			"Error Reading opencti object",
+			"Could not run the read query, unexpected error: "+err.Error(),
+		)
+
+		return "", false
+	}
+
+	_, decoded, err := normalizeJSON(data)
+	if err != nil {
+		diags.AddError(
+			"Error decoding opencti GraphQL result",
+			"Could not decode the result of the read query, unexpected error: "+err.Error(),
+		)
+
+		return "", false
+	}
+
+	if !model.ResultPath.IsNull() {
+		resultPath, err := compileJSONPath(model.ResultPath.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("result_path"), "Invalid result_path expression", err.Error())
+
+			return "", false
+		}
+
+		decoded = resultPath.search(decoded)
+	}
+
+	if decoded == nil || isEmptyGraphQLData(decoded) {
+		return "", false
+	}
+
+	result, err := encodeJSON(decoded)
+	if err != nil {
+		diags.AddError(
+			"Error encoding opencti GraphQL result",
+			"Could not encode the result of the read query, unexpected error: "+err.Error(),
+		)
+
+		return "", false
+	}
+
+	return result, true
+}
+
+// isEmptyGraphQLData tells whether the data of a response only has null fields, as returned
+// when the queried object does not exist.
+func isEmptyGraphQLData(value any) bool {
+	object, ok := value.(map[string]any)
+	if !ok || len(object) == 0 {
+		return false
+	}
+
+	for _, field := range object {
+		if field != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// withObjectID returns the variables of a document along with the ID of the object.
+func withObjectID(variables map[string]any, id string) map[string]any {
+	merged := maps.Clone(variables)
+	if merged == nil {
+		merged = map[string]any{}
+	}
+
+	merged["id"] = id
+
+	return merged
+}
+
+// checkGraphQLDocument adds an error diagnostic when a document is not made of operations of the expected type.
+func checkGraphQLDocument(diags *diag.Diagnostics, attribute path.Path, document types.String, expected string) {
+	if document.IsNull() || document.IsUnknown() {
+		return
+	}
+
+	for _, operation := range graphqlOperationTypes(document.ValueString()) {
+		if operation != expected {
+			diags.AddAttributeError(
+				attribute,
+				"Unexpected GraphQL operation",
+				fmt.Sprintf("The document contains a %s, a %s is expected.", operation, expected),
+			)
+
+			return
+		}
+	}
+}
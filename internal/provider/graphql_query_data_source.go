@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &graphqlQueryDataSource{}
+	_ datasource.DataSourceWithConfigure      = &graphqlQueryDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &graphqlQueryDataSource{}
+)
+
+// NewGraphQLQueryDataSource is a helper function to simplify the provider implementation.
+func NewGraphQLQueryDataSource() datasource.DataSource {
+	return &graphqlQueryDataSource{}
+}
+
+// graphqlQueryDataSource is the data source implementation.
+type graphqlQueryDataSource struct {
+	data *openctiProviderData
+}
+
+// graphqlQueryDataSourceModel maps the data source schema data.
+type graphqlQueryDataSourceModel struct {
+	Query     types.String  `tfsdk:"query"`
+	Variables types.String  `tfsdk:"variables"`
+	Extract   types.Map     `tfsdk:"extract"`
+	Result    types.String  `tfsdk:"result"`
+	Outputs   types.Dynamic `tfsdk:"outputs"`
+}
+
+// graphqlQueryRequirement is the minimum platform supporting the GraphQL queries of the provider.
+var graphqlQueryRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
+}
+
+// Metadata returns the data source type name.
+func (d *graphqlQueryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_graphql_query"
+}
+
+// Schema defines the schema for the data source.
+func (d *graphqlQueryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a GraphQL query on opencti, e.g. to read the settings which are not modelled by the provider. The query goes through the same transport, retries and logs as the rest of the provider.",
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "GraphQL query document. Mutations and subscriptions are refused, see the `opencti_graphql_mutation` resource.",
+			},
+			"variables": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "JSON encoded object of the variables of the query, e.g. with `jsonencode()`.",
+			},
+			"extract": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "JMESPath-style expressions evaluated on the `result`, by output name. The supported expressions are fields (`settings.platform_title`, `\"quoted key\"`), indexes (`[0]`, `[-1]`), projections (`[*]`, `.*`), flattening (`[]`) and filters comparing a relative expression, or the element itself with `@`, with a raw string or a JSON literal (`[?name == 'value']`, ``[?tags[0].count != `0`]``, `[?@ == 'value']`).",
+			},
+			"result": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "JSON encoded data of the response.",
+			},
+			"outputs": schema.DynamicAttribute{
+				Computed:            true,
+				MarkdownDescription: "Object with the values extracted by `extract`, typed after their JSON type: strings, numbers, booleans, tuples and objects. The expressions which do not match are null.",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *graphqlQueryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state graphqlQueryDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, operation := range graphqlOperationTypes(state.Query.ValueString()) {
+		if operation != "query" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("query"),
+				"Unsupported GraphQL operation",
+				fmt.Sprintf("The query document contains a %s, only queries can be run by a data source.", operation),
+			)
+
+			return
+		}
+	}
+
+	variables := parseGraphQLVariables(&resp.Diagnostics, path.Root("variables"), state.Variables)
+
+	extract := map[string]string{}
+
+	if !state.Extract.IsNull() {
+		resp.Diagnostics.Append(state.Extract.ElementsAs(ctx, &extract, false)...)
+	}
+
+	paths := map[string]jsonPath{}
+
+	for name, expr := range extract {
+		compiled, err := compileJSONPath(expr)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("extract").AtMapKey(name),
+				"Invalid extract expression",
+				"Could not parse the expression "+expr+": "+err.Error(),
+			)
+
+			continue
+		}
+
+		paths[name] = compiled
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Running opencti GraphQL query")
+
+	var data json.RawMessage
+
+	if err := d.data.graphql.Do(ctx, state.Query.ValueString(), variables, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error running opencti GraphQL query",
+			"Could not run the GraphQL query, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	result, decoded, err := normalizeJSON(data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error decoding opencti GraphQL result",
+			"Could not decode the GraphQL result, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	state.Result = types.StringValue(result)
+	state.Outputs = types.DynamicNull()
+
+	if len(paths) > 0 {
+		attributeTypes := map[string]attr.Type{}
+		attributes := map[string]attr.Value{}
+
+		for name, compiled := range paths {
+			value := terraformValue(ctx, compiled.search(decoded))
+
+			attributeTypes[name] = value.Type(ctx)
+			attributes[name] = value
+		}
+
+		state.Outputs = types.DynamicValue(types.ObjectValueMust(attributeTypes, attributes))
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *graphqlQueryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*openctiProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+// ValidateConfig checks that the platform supports the data source.
+func (d *graphqlQueryDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if d.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.data.platform.check(graphqlQueryRequirement, "opencti_graphql_query")...)
+}
+
+// parseGraphQLVariables decodes the JSON encoded variables of a GraphQL document.
+func parseGraphQLVariables(diags *diag.Diagnostics, attribute path.Path, value types.String) map[string]any {
+	if value.IsNull() || value.ValueString() == "" {
+		return nil
+	}
+
+	decoded, err := decodeJSON([]byte(value.ValueString()))
+	if err != nil {
+		diags.AddAttributeError(attribute, "Invalid GraphQL variables", "Could not decode the variables, unexpected error: "+err.Error())
+
+		return nil
+	}
+
+	variables, ok := decoded.(map[string]any)
+	if !ok {
+		diags.AddAttributeError(attribute, "Invalid GraphQL variables", "The variables must be a JSON object.")
+
+		return nil
+	}
+
+	return variables
+}
+
+// normalizeJSON returns the compact encoding of a JSON document, with the keys of the objects
+// sorted, along with its decoded value.
+func normalizeJSON(data []byte) (string, any, error) {
+	decoded, err := decodeJSON(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	encoded, err := encodeJSON(decoded)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return encoded, decoded, nil
+}
+
+// encodeJSON returns the compact encoding of a decoded JSON value, with the keys of the objects sorted.
+func encodeJSON(value any) (string, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
+
+// terraformValue converts a decoded JSON value into a Terraform value. The arrays become tuples,
+// as their elements may have different types, and null becomes a null string.
+func terraformValue(ctx context.Context, value any) attr.Value {
+	switch v := value.(type) {
+	case string:
+		return types.StringValue(v)
+	case bool:
+		return types.BoolValue(v)
+	case json.Number:
+		number, _, err := big.ParseFloat(string(v), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return types.StringValue(string(v))
+		}
+
+		return types.NumberValue(number)
+	case []any:
+		elementTypes := make([]attr.Type, 0, len(v))
+		elements := make([]attr.Value, 0, len(v))
+
+		for _, element := range v {
+			converted := terraformValue(ctx, element)
+
+			elementTypes = append(elementTypes, converted.Type(ctx))
+			elements = append(elements, converted)
+		}
+
+		return basetypes.NewTupleValueMust(elementTypes, elements)
+	case map[string]any:
+		attributeTypes := map[string]attr.Type{}
+		attributes := map[string]attr.Value{}
+
+		for key, element := range v {
+			converted := terraformValue(ctx, element)
+
+			attributeTypes[key] = converted.Type(ctx)
+			attributes[key] = converted
+		}
+
+		return types.ObjectValueMust(attributeTypes, attributes)
+	default:
+		return types.StringNull()
+	}
+}
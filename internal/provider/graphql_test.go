@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraphQLOperationTypes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		document string
+		want     []string
+	}{
+		{name: "empty", document: "", want: nil},
+		{name: "shorthand query", document: "{ me { id } }", want: []string{"query"}},
+		{name: "named query", document: "query Me { me { id } }", want: []string{"query"}},
+		{name: "mutation", document: "mutation Edit($id: ID!) { groupEdit(id: $id) { delete } }", want: []string{"mutation"}},
+		{name: "subscription", document: "subscription { me { id } }", want: []string{"subscription"}},
+		{name: "object default value", document: "query Q($a: Input = {b: 1}) { q(a: $a) }", want: []string{"query"}},
+		{name: "keyword in a comment", document: "# mutation\nquery { me { id } }", want: []string{"query"}},
+		{name: "keyword in a string", document: `query { search(text: "mutation { x }") { id } }`, want: []string{"query"}},
+		{name: "brace in a string", document: `query A { a } mutation B { b(s: "}") }`, want: []string{"query", "mutation"}},
+		{name: "block string", document: `mutation { note(text: """ { "query" } """) { id } }`, want: []string{"mutation"}},
+		{name: "fragment", document: "fragment F on Group { id } query { groups { ...F } }", want: []string{"query"}},
+		{name: "field named like an operation", document: "query { mutation { id } }", want: []string{"query"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := graphqlOperationTypes(tt.document); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("graphqlOperationTypes(%q) = %q, want %q", tt.document, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &groupDataSource{}
+	_ datasource.DataSourceWithConfigure      = &groupDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &groupDataSource{}
+)
+
+// NewGroupDataSource is a helper function to simplify the provider implementation.
+func NewGroupDataSource() datasource.DataSource {
+	return &groupDataSource{}
+}
+
+// groupDataSource is the data source implementation.
+type groupDataSource struct {
+	data *openctiProviderData
+}
+
+// groupDataSourceModel maps the data source schema data, shaped like groupResourceModel.
+type groupDataSourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Description        types.String `tfsdk:"description"`
+	Roles              types.List   `tfsdk:"roles"`
+	AllowedMarking     types.List   `tfsdk:"allowed_marking"`
+	MaxConfidenceLevel types.Int32  `tfsdk:"max_confidence_level"`
+	AutoNewMarking     types.Bool   `tfsdk:"auto_new_marking"`
+	DefaultAssignation types.Bool   `tfsdk:"default_assignation"`
+	MembersCount       types.Int64  `tfsdk:"members_count"`
+}
+
+// groupNode is a group as returned by the groupAttributes.
+type groupNode struct {
+	ID                   string `json:"id"`
+	Name                 string `json:"name"`
+	Description          string `json:"description"`
+	DefaultAssignation   bool   `json:"default_assignation"`
+	AutoNewMarking       bool   `json:"auto_new_marking"`
+	GroupConfidenceLevel struct {
+		MaxConfidence int32 `json:"max_confidence"`
+	} `json:"group_confidence_level"`
+	Roles struct {
+		Edges []struct {
+			Node struct {
+				Name string `json:"name"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"roles"`
+	AllowedMarking []struct {
+		Definition string `json:"definition"`
+	} `json:"allowed_marking"`
+	Members struct {
+		PageInfo struct {
+			GlobalCount int64 `json:"globalCount"`
+		} `json:"pageInfo"`
+	} `json:"members"`
+}
+
+// groupAttributes are the GraphQL attributes of the groups read by the data sources.
+const groupAttributes = "id name description default_assignation auto_new_marking group_confidence_level { max_confidence } " +
+	"roles { edges { node { name } } } allowed_marking { definition } members { pageInfo { globalCount } }"
+
+// groupDataAttributes returns the attributes of a group, computed unless they are lookup keys.
+func groupDataAttributes(lookup bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Optional:            lookup,
+			Computed:            true,
+			MarkdownDescription: "ID of the group.",
+		},
+		"name": schema.StringAttribute{
+			Optional:            lookup,
+			Computed:            true,
+			MarkdownDescription: "Name of the group, without the `name_prefix` and `name_suffix` of the provider.",
+		},
+		"description": schema.StringAttribute{
+			Computed: true,
+		},
+		"roles": schema.ListAttribute{
+			ElementType:         types.StringType,
+			Computed:            true,
+			MarkdownDescription: "Sorted names of the roles of the group.",
+		},
+		"allowed_marking": schema.ListAttribute{
+			ElementType:         types.StringType,
+			Computed:            true,
+			MarkdownDescription: "Sorted definitions of the marking definitions allowed to the group.",
+		},
+		"max_confidence_level": schema.Int32Attribute{
+			Computed: true,
+		},
+		"auto_new_marking": schema.BoolAttribute{
+			Computed: true,
+		},
+		"default_assignation": schema.BoolAttribute{
+			Computed: true,
+		},
+		"members_count": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Number of users member of the group.",
+		},
+	}
+}
+
+// groupData converts a group read from opencti into the data source model.
+func (d *openctiProviderData) groupData(ctx context.Context, group groupNode) (groupDataSourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	roles := []string{}
+	for _, role := range group.Roles.Edges {
+		roles = append(roles, d.stateName(role.Node.Name))
+	}
+
+	sort.Strings(roles)
+
+	rolesList, rolesDiags := types.ListValueFrom(ctx, types.StringType, roles)
+	diags.Append(rolesDiags...)
+
+	markings := []string{}
+	for _, marking := range group.AllowedMarking {
+		markings = append(markings, marking.Definition)
+	}
+
+	sort.Strings(markings)
+
+	markingsList, markingsDiags := types.ListValueFrom(ctx, types.StringType, markings)
+	diags.Append(markingsDiags...)
+
+	return groupDataSourceModel{
+		ID:                 types.StringValue(group.ID),
+		Name:               types.StringValue(d.stateName(group.Name)),
+		Description:        types.StringValue(d.unmarkDescription(group.Description)),
+		Roles:              rolesList,
+		AllowedMarking:     markingsList,
+		MaxConfidenceLevel: types.Int32Value(group.GroupConfidenceLevel.MaxConfidence),
+		AutoNewMarking:     types.BoolValue(group.AutoNewMarking),
+		DefaultAssignation: types.BoolValue(group.DefaultAssignation),
+		MembersCount:       types.Int64Value(group.Members.PageInfo.GlobalCount),
+	}, diags
+}
+
+// groupDataSourceRequirement is the minimum platform supporting the group confidence level, introduced in opencti 6.0.
+var groupDataSourceRequirement = platformRequirement{
+	MinVersion: "6.0.0",
+}
+
+// Metadata returns the data source type name.
+func (d *groupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+// Schema defines the schema for the data source.
+func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a group by `name` or `id`, e.g. a built-in group such as `Administrators` or a group managed elsewhere.",
+		Attributes:          groupDataAttributes(true),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config groupDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ID.IsNull() == config.Name.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Invalid opencti group lookup",
+			"Exactly one of the id and name attributes must be set.",
+		)
+
+		return
+	}
+
+	id := config.ID.ValueString()
+
+	if !config.Name.IsNull() {
+		var (
+			found bool
+			err   error
+		)
+
+		id, found, err = d.data.lookupNamedID(ctx, lookupGroup, config.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error looking up opencti group",
+				"Could not look up group "+config.Name.ValueString()+", unexpected error: "+err.Error(),
+			)
+
+			return
+		}
+
+		if !found {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"opencti group not found",
+				"No group is named "+config.Name.ValueString()+".",
+			)
+
+			return
+		}
+	}
+
+	var data struct {
+		Group *groupNode `json:"group"`
+	}
+
+	if err := d.data.graphql.Do(ctx, "query Group($id: String!) { group(id: $id) { "+groupAttributes+" } }", map[string]any{"id": id}, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading opencti group", err.Error(),
+		)
+
+		return
+	}
+
+	if data.Group == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"opencti group not found",
+			"No group has the ID "+id+".",
+		)
+
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Group read: %+v", *data.Group))
+
+	state, diags := d.data.groupData(ctx, *data.Group)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *groupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*openctiProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+// ValidateConfig checks that the platform supports the data source.
+func (d *groupDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if d.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.data.platform.check(groupDataSourceRequirement, "opencti_group")...)
+}
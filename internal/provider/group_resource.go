@@ -15,16 +15,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/weisshorn-cyd/gocti"
 	"github.com/weisshorn-cyd/gocti/graphql"
 	"github.com/weisshorn-cyd/gocti/system"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &groupResource{}
-	_ resource.ResourceWithConfigure   = &groupResource{}
-	_ resource.ResourceWithImportState = &groupResource{}
+	_ resource.Resource                   = &groupResource{}
+	_ resource.ResourceWithConfigure      = &groupResource{}
+	_ resource.ResourceWithImportState    = &groupResource{}
+	_ resource.ResourceWithModifyPlan     = &groupResource{}
+	_ resource.ResourceWithValidateConfig = &groupResource{}
 )
 
 // NewGroupResource is a helper function to simplify the provider implementation.
@@ -34,20 +35,28 @@ func NewGroupResource() resource.Resource {
 
 // groupResource is the resource implementation.
 type groupResource struct {
-	client *gocti.OpenCTIAPIClient
+	data *openctiProviderData
 }
 
 // groupResourceModel maps the resource schema data.
 type groupResourceModel struct {
-	ID                 types.String `tfsdk:"id"`
-	Name               types.String `tfsdk:"name"`
-	Description        types.String `tfsdk:"description"`
-	Roles              types.List   `tfsdk:"roles"`
-	AllowedMarking     types.List   `tfsdk:"allowed_marking"`
-	MaxConfidenceLevel types.Int32  `tfsdk:"max_confidence_level"`
-	AutoNewMarking     types.Bool   `tfsdk:"auto_new_marking"`
-	DefaultAssignation types.Bool   `tfsdk:"default_assignation"`
-	LastUpdated        types.String `tfsdk:"last_updated"`
+	ID                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	Description         types.String `tfsdk:"description"`
+	Roles               types.List   `tfsdk:"roles"`
+	AllowedMarking      types.List   `tfsdk:"allowed_marking"`
+	MaxConfidenceLevel  types.Int32  `tfsdk:"max_confidence_level"`
+	AutoNewMarking      types.Bool   `tfsdk:"auto_new_marking"`
+	DefaultAssignation  types.Bool   `tfsdk:"default_assignation"`
+	DefaultedAttributes types.List   `tfsdk:"defaulted_attributes"`
+	ImpersonateUser     types.String `tfsdk:"impersonate_user"`
+	Timeouts            types.Object `tfsdk:"timeouts"`
+	LastUpdated         types.String `tfsdk:"last_updated"`
+}
+
+// groupRequirement is the minimum platform supporting the group confidence level, introduced in opencti 6.0.
+var groupRequirement = platformRequirement{
+	MinVersion: "6.0.0",
 }
 
 // Metadata returns the resource type name.
@@ -83,20 +92,38 @@ func (r *groupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Required:    true,
 			},
 			"max_confidence_level": schema.Int32Attribute{
-				Required: true,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Required unless set in the group `defaults` of the provider.",
 			},
 			"auto_new_marking": schema.BoolAttribute{
-				Required: true,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Required unless set in the group `defaults` of the provider.",
 			},
 			"default_assignation": schema.BoolAttribute{
-				Required: true,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Required unless set in the group `defaults` of the provider.",
+			},
+			"defaulted_attributes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Names of the attributes whose value comes from the `defaults` of the provider.",
 			},
+			"impersonate_user": impersonateUserAttribute(),
+			"timeouts":         timeoutsAttribute(),
 		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "create", "opencti_group") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan groupResourceModel
 
@@ -107,12 +134,22 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "create", "opencti_group")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, "Creating group")
 
 	// Create new group
-	createdGroup, err := r.client.CreateGroup(ctx, "id name description default_assignation auto_new_marking group_confidence_level { max_confidence }", system.GroupAddInput{
-		Name:               plan.Name.ValueString(),
-		Description:        plan.Description.ValueString(),
+	createdGroup, err := r.data.client.CreateGroup(ctx, "id name description default_assignation auto_new_marking group_confidence_level { max_confidence }", system.GroupAddInput{
+		Name:               r.data.managedName(plan.Name.ValueString()),
+		Description:        r.data.markDescription(plan.Description.ValueString()),
 		DefaultAssignation: plan.DefaultAssignation.ValueBool(),
 		AutoNewMarking:     plan.AutoNewMarking.ValueBool(),
 		GroupConfidenceLevel: graphql.ConfidenceLevelInput{
@@ -130,15 +167,7 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	tflog.Debug(ctx, fmt.Sprintf("Group created: %+v", createdGroup))
 
-	existingRoles, err := r.client.ListRoles(ctx, "", true, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error listing roles",
-			"Could not create group, unexpected error: "+err.Error(),
-		)
-
-		return
-	}
+	r.data.cache.set(lookupGroup, createdGroup.Name, createdGroup.ID)
 
 	rolesAssigned := []string{}
 
@@ -146,61 +175,69 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 	for _, role := range plan.Roles.Elements() {
 		tflog.Info(ctx, fmt.Sprintf("Assigning role %s to group %s", role.String(), createdGroup.Name))
 
-		for _, remoteRole := range existingRoles {
-			if strings.Trim(role.String(), "\"") == remoteRole.Name {
-				if _, err := createdGroup.AssignRole(ctx, r.client, remoteRole.ID); err != nil {
-					resp.Diagnostics.AddError(
-						"Error assigning role to group",
-						"Could not create group, unexpected error: "+err.Error(),
-					)
+		roleName := strings.Trim(role.String(), "\"")
 
-					return
-				}
+		roleID, found, err := r.data.lookupNamedID(ctx, lookupRole, roleName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error listing roles",
+				"Could not create group, unexpected error: "+err.Error(),
+			)
 
-				rolesAssigned = append(rolesAssigned, remoteRole.Name)
+			return
+		}
 
-				break
-			}
+		if !found {
+			continue
 		}
-	}
 
-	sort.Strings(rolesAssigned)
+		if _, err := createdGroup.AssignRole(ctx, r.data.client, roleID); err != nil {
+			resp.Diagnostics.AddError(
+				"Error assigning role to group",
+				"Could not create group, unexpected error: "+err.Error(),
+			)
 
-	// Assign the marking definitions
-	existingMarkings, err := r.client.ListMarkingDefinitions(ctx, "id definition_type definition x_opencti_order x_opencti_color", true, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error listing markings",
-			"Could not create group, unexpected error: "+err.Error(),
-		)
+			return
+		}
 
-		return
+		rolesAssigned = append(rolesAssigned, roleName)
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Existing markings: %+v", existingMarkings))
+	sort.Strings(rolesAssigned)
 
 	markingsAssigned := []string{}
 
+	// Assign the marking definitions
 	for _, marking := range plan.AllowedMarking.Elements() {
 		tflog.Info(ctx, fmt.Sprintf("Assigning marking %s to group %s", marking, createdGroup.Name))
 
-		for _, remoteMarking := range existingMarkings {
-			if strings.Trim(marking.String(), "\"") == remoteMarking.Definition {
-				if _, err := createdGroup.AssignMarkingDefinition(ctx, r.client, remoteMarking.ID); err != nil {
-					resp.Diagnostics.AddError(
-						"Error assigning marking to group",
-						"Could not create group, unexpected error: "+err.Error(),
-					)
+		definition := strings.Trim(marking.String(), "\"")
+
+		markingID, found, err := r.data.lookupID(ctx, lookupMarking, definition)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error listing markings",
+				"Could not create group, unexpected error: "+err.Error(),
+			)
 
-					return
-				}
+			return
+		}
+
+		if !found {
+			continue
+		}
 
-				tflog.Debug(ctx, fmt.Sprintf("Assigning markings: %+v", remoteMarking))
-				markingsAssigned = append(markingsAssigned, remoteMarking.Definition)
+		if _, err := createdGroup.AssignMarkingDefinition(ctx, r.data.client, markingID); err != nil {
+			resp.Diagnostics.AddError(
+				"Error assigning marking to group",
+				"Could not create group, unexpected error: "+err.Error(),
+			)
 
-				break
-			}
+			return
 		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Assigning marking: %s (%s)", definition, markingID))
+		markingsAssigned = append(markingsAssigned, definition)
 	}
 
 	sort.Strings(markingsAssigned)
@@ -216,14 +253,17 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 	tflog.Debug(ctx, fmt.Sprintf("Markings assigned : %+v", markingsAssigned))
 
 	plan = groupResourceModel{
-		ID:                 types.StringValue(createdGroup.ID),
-		Name:               types.StringValue(createdGroup.Name),
-		Description:        types.StringValue(createdGroup.Description),
-		Roles:              rolesAssignedList,
-		AllowedMarking:     markingsAllowedList,
-		MaxConfidenceLevel: types.Int32Value(int32(createdGroup.GroupConfidenceLevel.MaxConfidence)),
-		AutoNewMarking:     types.BoolValue(createdGroup.AutoNewMarking),
-		DefaultAssignation: types.BoolValue(createdGroup.DefaultAssignation),
+		ID:                  types.StringValue(createdGroup.ID),
+		Name:                types.StringValue(r.data.stateName(createdGroup.Name)),
+		Description:         types.StringValue(r.data.unmarkDescription(createdGroup.Description)),
+		Roles:               rolesAssignedList,
+		AllowedMarking:      markingsAllowedList,
+		MaxConfidenceLevel:  types.Int32Value(int32(createdGroup.GroupConfidenceLevel.MaxConfidence)),
+		AutoNewMarking:      types.BoolValue(createdGroup.AutoNewMarking),
+		DefaultAssignation:  types.BoolValue(createdGroup.DefaultAssignation),
+		DefaultedAttributes: plan.DefaultedAttributes,
+		ImpersonateUser:     plan.ImpersonateUser,
+		Timeouts:            plan.Timeouts,
 	}
 
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
@@ -249,8 +289,12 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "read", "opencti_group")
+
 	// Read group from opencti
-	group, err := r.client.ReadGroup(ctx, "id name description roles { edges { node {id name} } } allowed_marking {id definition_type definition} default_assignation auto_new_marking group_confidence_level { max_confidence }", state.ID.ValueString())
+	group, err := r.data.client.ReadGroup(ctx, "id name description roles { edges { node {id name} } } allowed_marking {id definition_type definition} default_assignation auto_new_marking group_confidence_level { max_confidence }", state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading opencti group", err.Error(),
@@ -264,7 +308,7 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Parse the roles
 	roles := []string{}
 	for _, role := range group.Roles.Edges {
-		roles = append(roles, role.Node.Name)
+		roles = append(roles, r.data.stateName(role.Node.Name))
 	}
 
 	sort.Strings(roles)
@@ -284,8 +328,8 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	resp.Diagnostics.Append(diags...)
 
 	state.ID = types.StringValue(group.ID)
-	state.Name = types.StringValue(group.Name)
-	state.Description = types.StringValue(group.Description)
+	state.Name = types.StringValue(r.data.stateName(group.Name))
+	state.Description = types.StringValue(r.data.unmarkDescription(group.Description))
 	state.Roles = rolesList
 	state.AllowedMarking = markingsList
 	state.MaxConfidenceLevel = types.Int32Value(int32(group.GroupConfidenceLevel.MaxConfidence))
@@ -303,6 +347,11 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "update", "opencti_group") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan groupResourceModel
 
@@ -313,7 +362,17 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	group, err := r.client.ReadGroup(ctx, "id name description roles { edges { node {id name} } } allowed_marking {id definition_type definition} default_assignation auto_new_marking group_confidence_level { max_confidence }", plan.ID.ValueString())
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "update", "opencti_group")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.data.client.ReadGroup(ctx, "id name description roles { edges { node {id name} } } allowed_marking {id definition_type definition} default_assignation auto_new_marking group_confidence_level { max_confidence }", plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading opencti group", err.Error(),
@@ -337,10 +396,12 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Remove roles
 	for _, role := range group.Roles.Edges {
-		if !slices.Contains(rolesPlan, role.Node.Name) {
-			tflog.Info(ctx, fmt.Sprintf("Removing role: %s", role.Node.Name))
+		name := r.data.stateName(role.Node.Name)
+
+		if !slices.Contains(rolesPlan, name) {
+			tflog.Info(ctx, fmt.Sprintf("Removing role: %s", name))
 
-			if _, err := group.UnassignRole(ctx, r.client, role.Node.ID); err != nil {
+			if _, err := group.UnassignRole(ctx, r.data.client, role.Node.ID); err != nil {
 				resp.Diagnostics.AddError(
 					"Error Unassigning OpenCTI Role from Group", err.Error(),
 				)
@@ -348,39 +409,37 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 				return
 			}
 		} else {
-			rolesOfGroup = append(rolesOfGroup, role.Node.Name)
+			rolesOfGroup = append(rolesOfGroup, name)
 		}
 	}
 
 	// Add roles
-	existingRoles, err := r.client.ListRoles(ctx, "id name", true, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error listing roles", err.Error(),
-		)
-
-		return
-	}
-
 	for _, role := range rolesPlan {
 		if !slices.Contains(rolesOfGroup, role) {
 			tflog.Info(ctx, fmt.Sprintf("Adding role: %s", role))
 
-			for _, remoteRole := range existingRoles {
-				if role == remoteRole.Name {
-					if _, err := group.AssignRole(ctx, r.client, remoteRole.ID); err != nil {
-						resp.Diagnostics.AddError(
-							"Error assigning role to group", err.Error(),
-						)
+			roleID, found, err := r.data.lookupNamedID(ctx, lookupRole, role)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error listing roles", err.Error(),
+				)
 
-						return
-					}
+				return
+			}
 
-					rolesOfGroup = append(rolesOfGroup, remoteRole.Name)
+			if !found {
+				continue
+			}
 
-					break
-				}
+			if _, err := group.AssignRole(ctx, r.data.client, roleID); err != nil {
+				resp.Diagnostics.AddError(
+					"Error assigning role to group", err.Error(),
+				)
+
+				return
 			}
+
+			rolesOfGroup = append(rolesOfGroup, role)
 		}
 	}
 
@@ -409,7 +468,7 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		if !slices.Contains(markingsPlan, marking.Definition) {
 			tflog.Info(ctx, fmt.Sprintf("Removing marking definition: %s", marking.Definition))
 
-			if _, err := group.UnassignMarkingDefinition(ctx, r.client, marking.ID); err != nil {
+			if _, err := group.UnassignMarkingDefinition(ctx, r.data.client, marking.ID); err != nil {
 				resp.Diagnostics.AddError(
 					"Error Unassigning OpenCTI Marking Definition from Group", err.Error(),
 				)
@@ -422,34 +481,32 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Add markings
-	existingMarkings, err := r.client.ListMarkingDefinitions(ctx, "id definition", true, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error listing marking definitions", err.Error(),
-		)
-
-		return
-	}
-
 	for _, marking := range markingsPlan {
 		if !slices.Contains(markingsOfGroup, marking) {
 			tflog.Info(ctx, fmt.Sprintf("Adding marking definition: %s", marking))
 
-			for _, remoteMarking := range existingMarkings {
-				if marking == remoteMarking.Definition {
-					if _, err := group.AssignMarkingDefinition(ctx, r.client, remoteMarking.ID); err != nil {
-						resp.Diagnostics.AddError(
-							"Error assigning marking definition to group", err.Error(),
-						)
+			markingID, found, err := r.data.lookupID(ctx, lookupMarking, marking)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error listing marking definitions", err.Error(),
+				)
+
+				return
+			}
 
-						return
-					}
+			if !found {
+				continue
+			}
 
-					markingsOfGroup = append(markingsOfGroup, remoteMarking.Definition)
+			if _, err := group.AssignMarkingDefinition(ctx, r.data.client, markingID); err != nil {
+				resp.Diagnostics.AddError(
+					"Error assigning marking definition to group", err.Error(),
+				)
 
-					break
-				}
+				return
 			}
+
+			markingsOfGroup = append(markingsOfGroup, marking)
 		}
 	}
 
@@ -462,6 +519,15 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	plan.AllowedMarking = markingsList
 
+	// Update the description, which also stamps a group created before the ownership marker was set
+	if err := r.data.restamp(ctx, "opencti_group", plan.ID.ValueString(), "description", group.Description, r.data.markDescription(plan.Description.ValueString())); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating group description", err.Error(),
+		)
+
+		return
+	}
+
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	diags = resp.State.Set(ctx, plan)
@@ -474,6 +540,11 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "delete", "opencti_group") {
+		return
+	}
+
 	// Retrieve values from state
 	var state groupResourceModel
 
@@ -484,7 +555,17 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	if _, err := r.client.DeleteGroup(ctx, state.ID.ValueString()); err != nil {
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "delete", "opencti_group")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, state.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.data.client.DeleteGroup(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting OpenCTI Group",
 			"Could not delete group, unexpected error: "+err.Error(),
@@ -492,6 +573,8 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 		return
 	}
+
+	r.data.cache.remove(lookupGroup, r.data.managedName(state.Name.ValueString()))
 }
 
 // Configure adds the provider configured client to the resource.
@@ -502,17 +585,45 @@ func (r *groupResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*gocti.OpenCTIAPIClient)
+	data, ok := req.ProviderData.(*openctiProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *gocti.OpenCTIAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.data = data
+}
+
+// ModifyPlan merges the provider defaults into the plan, then checks that the provider allows
+// the planned change.
+func (r *groupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the provider is not configured, e.g. when its configuration is deferred
+	if r.data == nil {
+		return
+	}
+
+	// Merge the provider defaults into the plan, before checking the planned change
+	if !req.Plan.Raw.IsNull() {
+		r.data.applyGroupDefaults(ctx, req, resp)
+	}
+
+	resp.Diagnostics.Append(r.data.checkReadOnlyPlan(req.State, resp.Plan, "opencti_group")...)
+}
+
+// ValidateConfig checks that the attributes without provider defaults are set and that the
+// platform supports the resource.
+func (r *groupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.validateGroupDefaults(ctx, req.Config)...)
+	resp.Diagnostics.Append(r.data.platform.check(groupRequirement, "opencti_group")...)
 }
 
 func (r *groupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
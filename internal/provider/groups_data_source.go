@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &groupsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &groupsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &groupsDataSource{}
+)
+
+// NewGroupsDataSource is a helper function to simplify the provider implementation.
+func NewGroupsDataSource() datasource.DataSource {
+	return &groupsDataSource{}
+}
+
+// groupsDataSource is the data source implementation.
+type groupsDataSource struct {
+	data *openctiProviderData
+}
+
+// groupsDataSourceModel maps the data source schema data.
+type groupsDataSourceModel struct {
+	NameRegex          types.String           `tfsdk:"name_regex"`
+	DefaultAssignation types.Bool             `tfsdk:"default_assignation"`
+	WithRoles          types.Set              `tfsdk:"with_roles"`
+	WithAllowedMarking types.Set              `tfsdk:"with_allowed_marking"`
+	Groups             []groupDataSourceModel `tfsdk:"groups"`
+}
+
+// groupsRequirement is the minimum platform supporting the group confidence level of the listed groups, introduced in opencti 6.0.
+var groupsRequirement = platformRequirement{
+	MinVersion: "6.0.0",
+}
+
+// Metadata returns the data source type name.
+func (d *groupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_groups"
+}
+
+// Schema defines the schema for the data source.
+func (d *groupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the groups matching all the given filters, e.g. for access reviews.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Regular expression matched by the names of the groups, without the `name_prefix` and `name_suffix` of the provider, e.g. `^SOC-`. The literal prefix of an anchored expression is filtered by opencti.",
+			},
+			"default_assignation": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether the groups are assigned to the new users, filtered by opencti.",
+			},
+			"with_roles": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Names of roles which the groups must all have.",
+			},
+			"with_allowed_marking": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Definitions of marking definitions which the groups must all allow.",
+			},
+			"groups": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Groups matching the filters, sorted by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: groupDataAttributes(false),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *groupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state groupsDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var (
+		nameRegex                     *regexp.Regexp
+		withRoles, withAllowedMarking []string
+		filters                       []map[string]any
+	)
+
+	if !state.NameRegex.IsNull() {
+		var err error
+
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid name regular expression",
+				"Could not parse the regular expression, unexpected error: "+err.Error(),
+			)
+
+			return
+		}
+
+		// The names on opencti only match the literal prefix when they are not prefixed by the provider
+		prefix, _ := nameRegex.LiteralPrefix()
+		if prefix != "" && strings.HasPrefix(state.NameRegex.ValueString(), "^") && d.data.namePrefix == "" {
+			filters = append(filters, newFilter("name", "starts_with", prefix))
+		}
+	}
+
+	if !state.DefaultAssignation.IsNull() {
+		filters = append(filters, newFilter("default_assignation", "eq", strconv.FormatBool(state.DefaultAssignation.ValueBool())))
+	}
+
+	if !state.WithRoles.IsNull() {
+		resp.Diagnostics.Append(state.WithRoles.ElementsAs(ctx, &withRoles, false)...)
+	}
+
+	if !state.WithAllowedMarking.IsNull() {
+		resp.Diagnostics.Append(state.WithAllowedMarking.ElementsAs(ctx, &withAllowedMarking, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filterGroup map[string]any
+	if len(filters) > 0 {
+		filterGroup = andFilters(filters...)
+	}
+
+	nodes, err := d.data.graphql.listNodes(ctx, "groups", groupAttributes, filterGroup)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing opencti groups",
+			"Could not list groups, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	groups, err := decodeNodes[groupNode](nodes)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error decoding opencti groups",
+			"Could not decode groups, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	state.Groups = []groupDataSourceModel{}
+
+	for _, group := range groups {
+		model, diags := d.data.groupData(ctx, group)
+		resp.Diagnostics.Append(diags...)
+
+		// The filters of opencti are not necessarily exact, e.g. case insensitive, and cannot match the memberships
+		if nameRegex != nil && !nameRegex.MatchString(model.Name.ValueString()) {
+			continue
+		}
+
+		if !state.DefaultAssignation.IsNull() && !model.DefaultAssignation.Equal(state.DefaultAssignation) {
+			continue
+		}
+
+		if !containsAll(model.Roles, withRoles) || !containsAll(model.AllowedMarking, withAllowedMarking) {
+			continue
+		}
+
+		state.Groups = append(state.Groups, model)
+	}
+
+	sort.Slice(state.Groups, func(i, j int) bool {
+		return state.Groups[i].Name.ValueString() < state.Groups[j].Name.ValueString()
+	})
+
+	tflog.Debug(ctx, fmt.Sprintf("Groups found: %d", len(state.Groups)))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *groupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*openctiProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+// ValidateConfig checks that the platform supports the data source.
+func (d *groupsDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if d.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.data.platform.check(groupsRequirement, "opencti_groups")...)
+}
+
+// containsAll tells whether a list of strings contains all the values.
+func containsAll(list types.List, values []string) bool {
+	elements := []string{}
+
+	for _, element := range list.Elements() {
+		if value, ok := element.(types.String); ok {
+			elements = append(elements, value.ValueString())
+		}
+	}
+
+	for _, value := range values {
+		if !slices.Contains(elements, value) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/weisshorn-cyd/gocti"
+)
+
+const (
+	defaultReadyTimeout = 5 * time.Minute
+	maxReadyBackoff     = 30 * time.Second
+)
+
+// waitForReady creates the opencti client until its health check succeeds, waiting with an
+// exponential backoff between the attempts, or until the timeout is reached. The context given
+// to newClient is cancelled on timeout.
+func waitForReady(ctx context.Context, timeout time.Duration, newClient func(context.Context) (*gocti.OpenCTIAPIClient, error)) (*gocti.OpenCTIAPIClient, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	backoff := time.Second
+
+	for attempt := 1; ; attempt++ {
+		client, err := newClient(ctx)
+		if err == nil {
+			return client, nil
+		}
+
+		tflog.Info(ctx, "opencti is not ready yet", map[string]any{
+			"attempt": attempt,
+			"error":   err.Error(),
+			"wait":    backoff.String(),
+		})
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, fmt.Errorf("opencti is not ready after %s: %w", timeout, err)
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, maxReadyBackoff)
+	}
+}
+
+// parseBoolSetting returns a boolean given in the configuration or in an environment variable.
+func parseBoolSetting(diags *diag.Diagnostics, value types.Bool, attribute, env string) bool {
+	if !value.IsNull() {
+		return value.ValueBool()
+	}
+
+	v := os.Getenv(env)
+	if v == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid "+env+" value",
+			"The "+env+" environment variable must be a boolean, got: "+v,
+		)
+	}
+
+	return b
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// applicantHeader is the header used by opencti to act on behalf of another user.
+const applicantHeader = "opencti-applicant-id"
+
+// applicantContextKey is the context key of the ID of the impersonated user.
+type applicantContextKey struct{}
+
+// applicantTransport sets the applicant header on the requests, with the user impersonated
+// by the resource when there is one or with the user impersonated by the provider otherwise.
+type applicantTransport struct {
+	base http.RoundTripper
+
+	mu sync.Mutex
+	// applicant is the ID of the user impersonated by the provider, empty when there is none
+	applicant string
+	// resolve returns the applicant on first use, when it is not resolved by the provider configuration
+	resolve func(ctx context.Context) (string, error)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *applicantTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	applicant, ok := req.Context().Value(applicantContextKey{}).(string)
+	if !ok {
+		var err error
+
+		applicant, err = t.providerApplicant(req.Context())
+		if err != nil {
+			return nil, fmt.Errorf("resolving the opencti user to impersonate: %w", err)
+		}
+	}
+
+	if applicant == "" {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set(applicantHeader, applicant)
+
+	return t.base.RoundTrip(req)
+}
+
+// providerApplicant returns the ID of the user impersonated by the provider, resolving it on first use.
+// A failed resolution is retried by the next request.
+func (t *applicantTransport) providerApplicant(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.resolve != nil {
+		// The lookup itself is made without applicant, see userID
+		applicant, err := t.resolve(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		t.applicant, t.resolve = applicant, nil
+	}
+
+	return t.applicant, nil
+}
+
+// impersonateUserAttribute returns the schema of the impersonate_user attribute of the resources.
+func impersonateUserAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "ID or email of the user on behalf of whom the object is created, updated and deleted. Overrides the `impersonate_user` setting of the provider.",
+	}
+}
+
+// impersonate returns a context whose requests are made on behalf of the given user.
+// The context is returned unchanged when no user is given, the provider setting applies then.
+func impersonate(ctx context.Context, diags *diag.Diagnostics, data *openctiProviderData, user types.String) context.Context {
+	if user.IsNull() || user.IsUnknown() || user.ValueString() == "" {
+		return ctx
+	}
+
+	id, err := data.userID(ctx, user.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("impersonate_user"),
+			"Unable to Resolve Impersonated User",
+			"Could not resolve the opencti user to impersonate, unexpected error: "+err.Error(),
+		)
+
+		return ctx
+	}
+
+	return context.WithValue(ctx, applicantContextKey{}, id)
+}
+
+// userID returns the ID of a user given by ID or email.
+func (d *openctiProviderData) userID(ctx context.Context, user string) (string, error) {
+	if !strings.Contains(user, "@") {
+		return user, nil
+	}
+
+	// The lookup itself is made as the user of the token, who may be the only one allowed to list the users
+	ctx = context.WithValue(ctx, applicantContextKey{}, "")
+
+	id, found, err := d.lookupID(ctx, lookupUser, user)
+	if err != nil {
+		return "", err
+	}
+
+	if !found {
+		return "", errors.New("no opencti user with email " + user)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,395 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPathStepKind is the kind of a step of a JSON path.
+type jsonPathStepKind int
+
+const (
+	// stepField selects a field of an object.
+	stepField jsonPathStepKind = iota
+	// stepIndex selects an element of an array, counted from the end when negative.
+	stepIndex
+	// stepWildcard projects the rest of the path on the elements of an array or the values of an object.
+	stepWildcard
+	// stepFlatten flattens an array of arrays and projects the rest of the path on its elements.
+	stepFlatten
+	// stepFilter keeps the elements of an array matching a comparison and projects the rest of the path on them.
+	stepFilter
+)
+
+// jsonPathStep is a step of a JSON path.
+type jsonPathStep struct {
+	kind   jsonPathStepKind
+	field  string
+	index  int
+	filter *jsonPathFilter
+}
+
+// jsonPathFilter compares the value of a path relative to an array element with a literal.
+type jsonPathFilter struct {
+	path    jsonPath
+	equal   bool
+	literal any
+}
+
+// jsonPath is a compiled JMESPath-style expression, supporting the subset
+// foo.bar, foo[0], foo[-1], foo[*].bar, foo.*, foo[].bar, "quoted key" and
+// foo[?bar == 'value'], foo[?bar[0].baz != `json literal`], foo[?@ == 'value'].
+type jsonPath []jsonPathStep
+
+// compileJSONPath parses a JMESPath-style expression.
+func compileJSONPath(expr string) (jsonPath, error) {
+	var path jsonPath
+
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, errors.New("empty path")
+	}
+
+	pos := 0
+
+	// The path starts with a field, unless it directly selects elements of the root
+	if expr[0] != '[' {
+		step, next, err := parseJSONPathField(expr, pos)
+		if err != nil {
+			return nil, err
+		}
+
+		path = append(path, step)
+		pos = next
+	}
+
+	for pos < len(expr) {
+		switch expr[pos] {
+		case '.':
+			step, next, err := parseJSONPathField(expr, pos+1)
+			if err != nil {
+				return nil, err
+			}
+
+			path = append(path, step)
+			pos = next
+		case '[':
+			step, next, err := parseJSONPathBracket(expr, pos)
+			if err != nil {
+				return nil, err
+			}
+
+			path = append(path, step)
+			pos = next
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", expr[pos], pos)
+		}
+	}
+
+	return path, nil
+}
+
+// parseJSONPathField parses an identifier, a quoted identifier or an object wildcard starting at pos.
+func parseJSONPathField(expr string, pos int) (jsonPathStep, int, error) {
+	if pos >= len(expr) {
+		return jsonPathStep{}, pos, errors.New("missing field at the end of the path")
+	}
+
+	switch c := expr[pos]; {
+	case c == '*':
+		return jsonPathStep{kind: stepWildcard}, pos + 1, nil
+	case c == '"':
+		end := pos + 1
+		for end < len(expr) && expr[end] != '"' {
+			if expr[end] == '\\' {
+				end++
+			}
+
+			end++
+		}
+
+		if end >= len(expr) {
+			return jsonPathStep{}, pos, fmt.Errorf("unterminated quoted field at position %d", pos)
+		}
+
+		field, err := strconv.Unquote(expr[pos : end+1])
+		if err != nil {
+			return jsonPathStep{}, pos, fmt.Errorf("invalid quoted field at position %d: %w", pos, err)
+		}
+
+		return jsonPathStep{kind: stepField, field: field}, end + 1, nil
+	case isJSONPathIdentifier(c, true):
+		end := pos + 1
+		for end < len(expr) && isJSONPathIdentifier(expr[end], false) {
+			end++
+		}
+
+		return jsonPathStep{kind: stepField, field: expr[pos:end]}, end, nil
+	default:
+		return jsonPathStep{}, pos, fmt.Errorf("unexpected character %q at position %d, expected a field", c, pos)
+	}
+}
+
+// parseJSONPathBracket parses an index, a wildcard, a flatten or a filter starting with the bracket at pos.
+func parseJSONPathBracket(expr string, pos int) (jsonPathStep, int, error) {
+	end := closingJSONPathBracket(expr, pos)
+	if end < 0 {
+		return jsonPathStep{}, pos, fmt.Errorf("unterminated bracket at position %d", pos)
+	}
+
+	content := strings.TrimSpace(expr[pos+1 : end])
+
+	switch {
+	case content == "":
+		return jsonPathStep{kind: stepFlatten}, end + 1, nil
+	case content == "*":
+		return jsonPathStep{kind: stepWildcard}, end + 1, nil
+	case strings.HasPrefix(content, "?"):
+		filter, err := parseJSONPathFilter(strings.TrimSpace(content[1:]))
+		if err != nil {
+			return jsonPathStep{}, pos, fmt.Errorf("invalid filter at position %d: %w", pos, err)
+		}
+
+		return jsonPathStep{kind: stepFilter, filter: filter}, end + 1, nil
+	default:
+		index, err := strconv.Atoi(content)
+		if err != nil {
+			return jsonPathStep{}, pos, fmt.Errorf("invalid index %q at position %d", content, pos)
+		}
+
+		return jsonPathStep{kind: stepIndex, index: index}, end + 1, nil
+	}
+}
+
+// closingJSONPathBracket returns the position of the bracket closing the one at pos, skipping the
+// literals and the nested brackets.
+func closingJSONPathBracket(expr string, pos int) int {
+	var quote byte
+
+	depth := 0
+
+	for i := pos; i < len(expr); i++ {
+		c := expr[i]
+
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '\'' || c == '`' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// jsonPathComparison returns the position of the == or != operator of a filter, skipping the
+// literals and the nested brackets, and whether it is an equality. The position is -1 without operator.
+func jsonPathComparison(content string) (int, bool) {
+	var quote byte
+
+	depth := 0
+
+	for i := 0; i < len(content)-1; i++ {
+		c := content[i]
+
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '\'' || c == '`' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case depth == 0 && (c == '=' || c == '!') && content[i+1] == '=':
+			return i, c == '='
+		}
+	}
+
+	return -1, false
+}
+
+// parseJSONPathFilter parses the comparison of a filter, such as name == 'value'.
+func parseJSONPathFilter(content string) (*jsonPathFilter, error) {
+	filter := &jsonPathFilter{}
+
+	i, equal := jsonPathComparison(content)
+	if i < 0 {
+		return nil, errors.New("expected a == or != comparison")
+	}
+
+	filter.equal = equal
+
+	// @ compares the element itself
+	if lhs := strings.TrimSpace(content[:i]); lhs != "@" {
+		path, err := compileJSONPath(lhs)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.path = path
+	}
+
+	var err error
+
+	literal := strings.TrimSpace(content[i+2:])
+
+	switch {
+	case len(literal) >= 2 && literal[0] == '\'' && literal[len(literal)-1] == '\'':
+		filter.literal = strings.ReplaceAll(literal[1:len(literal)-1], `\'`, "'")
+	case len(literal) >= 2 && literal[0] == '`' && literal[len(literal)-1] == '`':
+		filter.literal, err = decodeJSON([]byte(literal[1 : len(literal)-1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON literal %s: %w", literal, err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid literal %q, expected a 'raw string' or a `JSON` literal", literal)
+	}
+
+	return filter, nil
+}
+
+// isJSONPathIdentifier tells whether a character can be part of an unquoted field.
+func isJSONPathIdentifier(c byte, first bool) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (!first && c >= '0' && c <= '9')
+}
+
+// search evaluates the path on a decoded JSON value. It returns nil when the path does not match.
+func (p jsonPath) search(value any) any {
+	if len(p) == 0 || value == nil {
+		return value
+	}
+
+	step, rest := p[0], p[1:]
+
+	switch step.kind {
+	case stepField:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		return rest.search(object[step.field])
+	case stepIndex:
+		array, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+
+		index := step.index
+		if index < 0 {
+			index += len(array)
+		}
+
+		if index < 0 || index >= len(array) {
+			return nil
+		}
+
+		return rest.search(array[index])
+	case stepWildcard:
+		switch v := value.(type) {
+		case []any:
+			return rest.project(v)
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+
+			sort.Strings(keys)
+
+			values := make([]any, 0, len(v))
+			for _, key := range keys {
+				values = append(values, v[key])
+			}
+
+			return rest.project(values)
+		default:
+			return nil
+		}
+	case stepFlatten:
+		array, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+
+		flattened := []any{}
+
+		for _, element := range array {
+			if sub, ok := element.([]any); ok {
+				flattened = append(flattened, sub...)
+			} else {
+				flattened = append(flattened, element)
+			}
+		}
+
+		return rest.project(flattened)
+	case stepFilter:
+		array, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+
+		kept := []any{}
+
+		for _, element := range array {
+			if reflect.DeepEqual(step.filter.path.search(element), step.filter.literal) == step.filter.equal {
+				kept = append(kept, element)
+			}
+		}
+
+		return rest.project(kept)
+	}
+
+	return nil
+}
+
+// project evaluates the path on every element, leaving out the elements which do not match.
+func (p jsonPath) project(elements []any) any {
+	results := []any{}
+
+	for _, element := range elements {
+		if result := p.search(element); result != nil {
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// decodeJSON decodes a JSON document, keeping the numbers as json.Number to preserve their precision.
+func decodeJSON(data []byte) (any, error) {
+	var value any
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+
+	return value, nil
+}
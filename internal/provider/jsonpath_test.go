@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJSONPathSearch(t *testing.T) {
+	t.Parallel()
+
+	document := `{
+		"settings": {"platform_title": "OpenCTI", "enabled": true},
+		"groups": {
+			"edges": [
+				{"node": {"name": "SOC", "count": 2, "tags": ["x", "y"]}},
+				{"node": {"name": "CERT", "count": 0, "tags": ["z"]}},
+				{"node": {"name": "it's", "count": 1, "tags": []}}
+			]
+		},
+		"matrix": [[1, 2], [3], 4],
+		"names": ["a", "b", "a"],
+		"quoted key": {"a]b": 1}
+	}`
+
+	value, err := decodeJSON([]byte(document))
+	if err != nil {
+		t.Fatalf("decoding the document: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{name: "field", expr: "settings.platform_title", want: `"OpenCTI"`},
+		{name: "boolean", expr: "settings.enabled", want: `true`},
+		{name: "missing field", expr: "settings.missing", want: `null`},
+		{name: "field of a scalar", expr: "settings.platform_title.name", want: `null`},
+		{name: "index", expr: "groups.edges[0].node.name", want: `"SOC"`},
+		{name: "negative index", expr: "groups.edges[-1].node.count", want: `1`},
+		{name: "index out of range", expr: "groups.edges[3]", want: `null`},
+		{name: "index of an object", expr: "settings[0]", want: `null`},
+		{name: "root index", expr: "[0]", want: `null`},
+		{name: "array wildcard", expr: "groups.edges[*].node.name", want: `["SOC","CERT","it's"]`},
+		{name: "object wildcard", expr: "settings.*", want: `[true,"OpenCTI"]`},
+		{name: "flatten", expr: "matrix[]", want: `[1,2,3,4]`},
+		{name: "quoted field", expr: `"quoted key"."a]b"`, want: `1`},
+		{name: "filter on a string", expr: "groups.edges[?node.name == 'CERT'].node.count", want: `[0]`},
+		{name: "filter on a JSON literal", expr: "groups.edges[?node.count != `0`].node.name", want: `["SOC","it's"]`},
+		{name: "filter on an escaped quote", expr: `groups.edges[?node.name == 'it\'s'].node.count`, want: `[1]`},
+		{name: "filter on a nested index", expr: "groups.edges[?node.tags[0] == 'x'].node.name", want: `["SOC"]`},
+		{name: "filter on a nested filter", expr: "groups.edges[?node.tags[?@ == 'z'] == `[\"z\"]`].node.name", want: `["CERT"]`},
+		{name: "filter with brackets in the literal", expr: "groups.edges[?node.name == '[x]'].node.name", want: `[]`},
+		{name: "filter on the element", expr: "names[?@ == 'a']", want: `["a","a"]`},
+		{name: "projection of missing fields", expr: "groups.edges[*].node.missing", want: `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path, err := compileJSONPath(tt.expr)
+			if err != nil {
+				t.Fatalf("compileJSONPath(%q) returned an error: %v", tt.expr, err)
+			}
+
+			want, err := decodeJSON([]byte(tt.want))
+			if err != nil {
+				t.Fatalf("decoding %s: %v", tt.want, err)
+			}
+
+			if got := path.search(value); !reflect.DeepEqual(got, want) {
+				encoded, _ := json.Marshal(got)
+				t.Errorf("search(%q) = %s, want %s", tt.expr, encoded, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileJSONPathErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "empty", expr: " "},
+		{name: "double dot", expr: "a..b"},
+		{name: "trailing dot", expr: "a."},
+		{name: "unterminated bracket", expr: "a[0"},
+		{name: "unterminated nested bracket", expr: "a[?b[0] == 'x'"},
+		{name: "invalid index", expr: "a[x]"},
+		{name: "unterminated quoted field", expr: `a."b`},
+		{name: "unexpected character", expr: "a-b"},
+		{name: "filter without comparison", expr: "a[?b]"},
+		{name: "filter with an unquoted literal", expr: "a[?b == c]"},
+		{name: "filter with an invalid JSON literal", expr: "a[?b == `{`]"},
+		{name: "filter with an invalid path", expr: "a[?b.. == 'c']"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := compileJSONPath(tt.expr); err == nil {
+				t.Errorf("compileJSONPath(%q) returned no error", tt.expr)
+			}
+		})
+	}
+}
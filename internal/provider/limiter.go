@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// limitTransport limits the number of concurrent requests and the rate of the requests
+// sent to opencti. It is shared by all the resources and data sources. The queued requests
+// wait until the timeout of their operation, the attempts are only bounded once sent.
+type limitTransport struct {
+	base http.RoundTripper
+	// slots holds a token per request in flight, it is nil when the concurrency is not limited
+	slots chan struct{}
+	// interval is the minimum delay between two requests, zero when the rate is not limited
+	interval time.Duration
+
+	mu sync.Mutex
+	// next is the earliest time at which the next request can be sent
+	next time.Time
+}
+
+// newLimitTransport reads the limit settings from the provider configuration, falling back
+// to the OPENCTI_* environment variables, and wraps the given transport.
+func newLimitTransport(base http.RoundTripper, config openctiProviderModel) (*limitTransport, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	t := &limitTransport{
+		base: base,
+	}
+
+	var maxConcurrentRequests int64
+
+	if v := os.Getenv("OPENCTI_MAX_CONCURRENT_REQUESTS"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid OPENCTI_MAX_CONCURRENT_REQUESTS value",
+				"The OPENCTI_MAX_CONCURRENT_REQUESTS environment variable must be an integer, got: "+v,
+			)
+		}
+
+		maxConcurrentRequests = n
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		maxConcurrentRequests = config.MaxConcurrentRequests.ValueInt64()
+	}
+
+	if maxConcurrentRequests < 0 {
+		diags.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid opencti max concurrent requests",
+			"The maximum number of concurrent requests cannot be negative.",
+		)
+	} else if maxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	var requestsPerSecond float64
+
+	if v := os.Getenv("OPENCTI_REQUESTS_PER_SECOND"); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("requests_per_second"),
+				"Invalid OPENCTI_REQUESTS_PER_SECOND value",
+				"The OPENCTI_REQUESTS_PER_SECOND environment variable must be a number, got: "+v,
+			)
+		}
+
+		requestsPerSecond = rate
+	}
+
+	if !config.RequestsPerSecond.IsNull() {
+		requestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+
+	if requestsPerSecond < 0 {
+		diags.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid opencti requests per second",
+			"The number of requests per second cannot be negative.",
+		)
+	} else if requestsPerSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+
+	return t, diags
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if err := t.waitRate(ctx); err != nil {
+		return nil, err
+	}
+
+	if t.slots == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	if err := t.acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.release()
+
+		return nil, err
+	}
+
+	// The request is in flight until its response is read
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: t.release}
+
+	return resp, nil
+}
+
+// waitRate waits until the rate limit allows a new request.
+func (t *limitTransport) waitRate(ctx context.Context) error {
+	if t.interval == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+
+	wait := t.next.Sub(now)
+	t.next = t.next.Add(t.interval)
+
+	t.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	tflog.Debug(ctx, "Waiting for the opencti rate limit", map[string]any{"wait": wait.String()})
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// acquire waits for a free request slot.
+func (t *limitTransport) acquire(ctx context.Context) error {
+	select {
+	case t.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	tflog.Debug(ctx, "Waiting for a free opencti request slot", map[string]any{"max_concurrent_requests": cap(t.slots)})
+
+	start := time.Now()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case t.slots <- struct{}{}:
+		tflog.Debug(ctx, "Acquired an opencti request slot", map[string]any{"wait": time.Since(start).String()})
+
+		return nil
+	}
+}
+
+// release frees a request slot.
+func (t *limitTransport) release() {
+	<-t.slots
+}
+
+// releaseBody releases the request slot once the response body is closed.
+type releaseBody struct {
+	io.ReadCloser
+
+	once    sync.Once
+	release func()
+}
+
+// Close implements io.Closer.
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLimitTransportQueuesRequests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		maxConcurrent int
+		interval      time.Duration
+		delay         time.Duration
+		requests      int
+		minElapsed    time.Duration
+	}{
+		{name: "concurrency", maxConcurrent: 1, delay: 40 * time.Millisecond, requests: 4, minElapsed: 160 * time.Millisecond},
+		{name: "rate", interval: 40 * time.Millisecond, requests: 4, minElapsed: 120 * time.Millisecond},
+		{name: "concurrency and rate", maxConcurrent: 2, interval: 20 * time.Millisecond, delay: 40 * time.Millisecond, requests: 4, minElapsed: 80 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu                    sync.Mutex
+				inFlight, maxInFlight int
+			)
+
+			base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				mu.Unlock()
+
+				defer func() {
+					mu.Lock()
+					inFlight--
+					mu.Unlock()
+				}()
+
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(tt.delay):
+					return newResponse(http.StatusOK, "", `{"data": {}}`), nil
+				}
+			})
+
+			transport := &limitTransport{
+				// Each attempt is bounded once it leaves the queue, far below the total wait
+				base:     &timeoutTransport{base: base, timeout: tt.delay + 30*time.Millisecond},
+				interval: tt.interval,
+			}
+
+			if tt.maxConcurrent > 0 {
+				transport.slots = make(chan struct{}, tt.maxConcurrent)
+			}
+
+			requests := make([]*http.Request, tt.requests)
+			for i := range requests {
+				requests[i] = newGraphQLRequest(t, testQuery)
+			}
+
+			start := time.Now()
+
+			var wg sync.WaitGroup
+
+			for _, req := range requests {
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+
+					resp, err := transport.RoundTrip(req)
+					if err != nil {
+						t.Errorf("queued request failed: %v", err)
+
+						return
+					}
+					resp.Body.Close()
+				}()
+			}
+
+			wg.Wait()
+
+			if elapsed := time.Since(start); elapsed < tt.minElapsed {
+				t.Errorf("%d requests took %s, want at least %s", tt.requests, elapsed, tt.minElapsed)
+			}
+
+			if tt.maxConcurrent > 0 && maxInFlight > tt.maxConcurrent {
+				t.Errorf("%d requests were in flight, want at most %d", maxInFlight, tt.maxConcurrent)
+			}
+		})
+	}
+}
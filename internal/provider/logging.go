@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// goctiSubsystem is the tflog subsystem receiving the gocti logs.
+const goctiSubsystem = "gocti"
+
+// sensitiveLogKeys are the keys whose values are masked in the gocti logs,
+// including inside the GraphQL variables.
+var sensitiveLogKeys = []string{
+	"password",
+	"token",
+	"secret",
+	"authorization",
+}
+
+// levelTrace is the slog level below debug, written as tflog traces.
+const levelTrace = slog.LevelDebug - 4
+
+// parseLogLevel returns the slog level matching a log_level setting. The default is the
+// info level the gocti client was created with before the setting existed.
+func parseLogLevel(diags *diag.Diagnostics, value string) slog.Level {
+	switch strings.ToLower(value) {
+	case "trace":
+		return levelTrace
+	case "debug":
+		return slog.LevelDebug
+	case "", "info":
+		return slog.LevelInfo
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		diags.AddAttributeError(
+			path.Root("log_level"),
+			"Invalid opencti log level",
+			"The log level must be one of trace, debug, info, warn or error, got: "+value,
+		)
+
+		return slog.LevelInfo
+	}
+}
+
+// newGoctiLogger returns a slog logger writing the gocti logs to the gocti tflog subsystem,
+// whose verbosity follows TF_LOG_PROVIDER_OPENCTI or TF_LOG_PROVIDER_OPENCTI_GOCTI.
+func newGoctiLogger(ctx context.Context, level slog.Level, secrets ...string) *slog.Logger {
+	ctx = tflog.NewSubsystem(ctx, goctiSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_OPENCTI", "GOCTI"))
+
+	for _, secret := range secrets {
+		if secret != "" {
+			ctx = tflog.SubsystemMaskLogStrings(ctx, goctiSubsystem, secret)
+		}
+	}
+
+	return slog.New(&tflogHandler{ctx: ctx, level: level})
+}
+
+// tflogHandler is a slog handler writing to a tflog subsystem.
+type tflogHandler struct {
+	// ctx holds the tflog logger, the context of the records does not necessarily have one
+	ctx    context.Context
+	level  slog.Level
+	attrs  []slog.Attr
+	groups []string
+}
+
+// Enabled implements slog.Handler.
+func (h *tflogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+// Handle implements slog.Handler.
+func (h *tflogHandler) Handle(_ context.Context, record slog.Record) error {
+	fields := map[string]any{}
+
+	for _, attr := range h.attrs {
+		addLogField(fields, attr)
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		addLogField(fields, h.qualify(attr))
+
+		return true
+	})
+
+	switch {
+	case record.Level >= slog.LevelError:
+		tflog.SubsystemError(h.ctx, goctiSubsystem, record.Message, fields)
+	case record.Level >= slog.LevelWarn:
+		tflog.SubsystemWarn(h.ctx, goctiSubsystem, record.Message, fields)
+	case record.Level >= slog.LevelInfo:
+		tflog.SubsystemInfo(h.ctx, goctiSubsystem, record.Message, fields)
+	case record.Level >= slog.LevelDebug:
+		tflog.SubsystemDebug(h.ctx, goctiSubsystem, record.Message, fields)
+	default:
+		tflog.SubsystemTrace(h.ctx, goctiSubsystem, record.Message, fields)
+	}
+
+	return nil
+}
+
+// WithAttrs implements slog.Handler.
+func (h *tflogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append([]slog.Attr{}, h.attrs...)
+
+	for _, attr := range attrs {
+		clone.attrs = append(clone.attrs, h.qualify(attr))
+	}
+
+	return &clone
+}
+
+// WithGroup implements slog.Handler.
+func (h *tflogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	clone := *h
+	clone.groups = append(append([]string{}, h.groups...), name)
+
+	return &clone
+}
+
+// qualify prefixes the key of an attribute with the current groups.
+func (h *tflogHandler) qualify(attr slog.Attr) slog.Attr {
+	if len(h.groups) > 0 {
+		attr.Key = strings.Join(h.groups, ".") + "." + attr.Key
+	}
+
+	return attr
+}
+
+// addLogField adds an attribute to the tflog fields, flattening the groups and masking the secrets.
+func addLogField(fields map[string]any, attr slog.Attr) {
+	value := attr.Value.Resolve()
+
+	if value.Kind() == slog.KindGroup {
+		for _, sub := range value.Group() {
+			if attr.Key != "" {
+				sub.Key = attr.Key + "." + sub.Key
+			}
+
+			addLogField(fields, sub)
+		}
+
+		return
+	}
+
+	if attr.Key == "" {
+		return
+	}
+
+	if isSensitiveLogKey(attr.Key) {
+		fields[attr.Key] = "***"
+
+		return
+	}
+
+	if value.Kind() != slog.KindAny {
+		fields[attr.Key] = value.Any()
+
+		return
+	}
+
+	// Structured values such as the GraphQL variables are masked recursively
+	var decoded any
+
+	data, err := json.Marshal(value.Any())
+	if err != nil || json.Unmarshal(data, &decoded) != nil {
+		fields[attr.Key] = value.String()
+
+		return
+	}
+
+	fields[attr.Key] = maskSecrets(decoded)
+}
+
+// maskSecrets replaces the values of the sensitive keys of a decoded JSON value.
+func maskSecrets(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, sub := range v {
+			if isSensitiveLogKey(key) {
+				v[key] = "***"
+			} else {
+				v[key] = maskSecrets(sub)
+			}
+		}
+	case []any:
+		for i, sub := range v {
+			v[i] = maskSecrets(sub)
+		}
+	}
+
+	return value
+}
+
+// isSensitiveLogKey tells whether the value of a log key has to be masked.
+func isSensitiveLogKey(key string) bool {
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		key = key[i+1:]
+	}
+
+	key = strings.ToLower(key)
+
+	for _, sensitive := range sensitiveLogKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		value     string
+		want      slog.Level
+		wantError bool
+	}{
+		{name: "default", value: "", want: slog.LevelInfo},
+		{name: "trace", value: "trace", want: levelTrace},
+		{name: "debug", value: "debug", want: slog.LevelDebug},
+		{name: "info", value: "info", want: slog.LevelInfo},
+		{name: "warn", value: "warn", want: slog.LevelWarn},
+		{name: "warning", value: "warning", want: slog.LevelWarn},
+		{name: "error", value: "error", want: slog.LevelError},
+		{name: "upper case", value: "DEBUG", want: slog.LevelDebug},
+		{name: "invalid", value: "verbose", want: slog.LevelInfo, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+
+			if got := parseLogLevel(&diags, tt.value); got != tt.want {
+				t.Errorf("parseLogLevel(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+
+			if diags.HasError() != tt.wantError {
+				t.Errorf("parseLogLevel(%q) errors = %v, want error %t", tt.value, diags.Errors(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestIsSensitiveLogKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{name: "password", key: "password", want: true},
+		{name: "token", key: "api_token", want: true},
+		{name: "secret", key: "client_secret", want: true},
+		{name: "authorization header", key: "Authorization", want: true},
+		{name: "nested key", key: "variables.input.password", want: true},
+		{name: "sensitive group of a plain key", key: "token.length", want: false},
+		{name: "plain key", key: "query", want: false},
+		{name: "empty", key: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isSensitiveLogKey(tt.key); got != tt.want {
+				t.Errorf("isSensitiveLogKey(%q) = %t, want %t", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskSecrets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "scalar", value: `"password"`, want: `"password"`},
+		{name: "flat object", value: `{"email": "admin@opencti.io", "password": "p"}`, want: `{"email": "admin@opencti.io", "password": "***"}`},
+		{name: "nested object", value: `{"input": {"name": "x", "api_token": "t"}}`, want: `{"input": {"name": "x", "api_token": "***"}}`},
+		{name: "sensitive object", value: `{"Authorization": {"scheme": "Bearer"}}`, want: `{"Authorization": "***"}`},
+		{name: "array of objects", value: `[{"secret": 1}, {"id": 2}]`, want: `[{"secret": "***"}, {"id": 2}]`},
+		{name: "nested arrays", value: `{"users": [[{"token": "t"}]]}`, want: `{"users": [[{"token": "***"}]]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var value, want any
+
+			if err := json.Unmarshal([]byte(tt.value), &value); err != nil {
+				t.Fatalf("decoding %s: %v", tt.value, err)
+			}
+
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatalf("decoding %s: %v", tt.want, err)
+			}
+
+			if got := maskSecrets(value); !reflect.DeepEqual(got, want) {
+				encoded, _ := json.Marshal(got)
+				t.Errorf("maskSecrets(%s) = %s, want %s", tt.value, encoded, tt.want)
+			}
+		})
+	}
+}
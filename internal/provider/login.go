@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+)
+
+// login authenticates on opencti with an email and a password and returns the API token of the user.
+func login(ctx context.Context, transport http.RoundTripper, url, email, password string) (string, error) {
+	// The session cookie set by the login is needed if the token is not returned directly
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return "", fmt.Errorf("creating cookie jar: %w", err)
+	}
+
+	client := &graphqlClient{
+		url: url,
+		httpClient: &http.Client{
+			Transport: transport,
+			Jar:       jar,
+		},
+	}
+
+	var loginData struct {
+		Token string `json:"token"`
+	}
+
+	if err := client.Do(ctx, "mutation Login($input: UserLoginInput!) { token(input: $input) }", map[string]any{
+		"input": map[string]any{
+			"email":    email,
+			"password": password,
+		},
+	}, &loginData); err != nil {
+		return "", fmt.Errorf("logging in as %s: %w", email, err)
+	}
+
+	if loginData.Token != "" {
+		return loginData.Token, nil
+	}
+
+	var meData struct {
+		Me struct {
+			APIToken string `json:"api_token"`
+		} `json:"me"`
+	}
+
+	if err := client.Do(ctx, "query { me { api_token } }", nil, &meData); err != nil {
+		return "", fmt.Errorf("retrieving the API token of %s: %w", email, err)
+	}
+
+	if meData.Me.APIToken == "" {
+		return "", errors.New("opencti did not return an API token for " + email)
+	}
+
+	return meData.Me.APIToken, nil
+}
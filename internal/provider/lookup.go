@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// lookupMaxResults bounds the number of objects returned by a lookup, only duplicates are expected.
+const lookupMaxResults = 100
+
+// lookupQuery describes how opencti resolves an object by its natural key.
+type lookupQuery struct {
+	// field is the GraphQL field listing the objects
+	field string
+	// key is both the filter key and the attribute holding the natural key
+	key string
+	// search is set when the field does not accept filters, the results are then matched exactly
+	search bool
+}
+
+// lookupQueries are the queries of the kinds of objects resolved by natural key.
+var lookupQueries = map[lookupKind]lookupQuery{
+	lookupGroup:   {field: "groups", key: "name"},
+	lookupRole:    {field: "roles", key: "name", search: true},
+	lookupMarking: {field: "markingDefinitions", key: "definition"},
+	lookupUser:    {field: "users", key: "user_email"},
+}
+
+// resolveID returns the ID of an object by its natural key, filtering the objects on the server side.
+// found is false when no object matches the key.
+func resolveID(ctx context.Context, client *graphqlClient, kind lookupKind, key string) (string, bool, error) {
+	lookup, ok := lookupQueries[kind]
+	if !ok {
+		return "", false, fmt.Errorf("cannot look up the objects of kind %s", kind)
+	}
+
+	if client == nil {
+		return "", false, errors.New("the opencti client is not configured")
+	}
+
+	var (
+		query     string
+		variables map[string]any
+	)
+
+	if lookup.search {
+		query = fmt.Sprintf("query Lookup($first: Int, $search: String) { %s(first: $first, search: $search) { edges { node { id %s } } } }", lookup.field, lookup.key)
+		variables = map[string]any{
+			"first":  lookupMaxResults,
+			"search": key,
+		}
+	} else {
+		query = fmt.Sprintf("query Lookup($first: Int, $filters: FilterGroup) { %s(first: $first, filters: $filters) { edges { node { id %s } } } }", lookup.field, lookup.key)
+		variables = map[string]any{
+			"first":   lookupMaxResults,
+			"filters": eqFilter(lookup.key, key),
+		}
+	}
+
+	tflog.Debug(ctx, "Looking up opencti object", map[string]any{"kind": string(kind), lookup.key: key})
+
+	var data map[string]struct {
+		Edges []struct {
+			Node map[string]string `json:"node"`
+		} `json:"edges"`
+	}
+
+	if err := client.Do(ctx, query, variables, &data); err != nil {
+		return "", false, fmt.Errorf("looking up %s %s: %w", kind, key, err)
+	}
+
+	// The search and the filters are not necessarily exact, e.g. case insensitive
+	for _, edge := range data[lookup.field].Edges {
+		if edge.Node[lookup.key] == key {
+			return edge.Node["id"], true, nil
+		}
+	}
+
+	return "", false, nil
+}
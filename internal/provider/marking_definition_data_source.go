@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &markingDefinitionDataSource{}
+	_ datasource.DataSourceWithConfigure      = &markingDefinitionDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &markingDefinitionDataSource{}
+)
+
+// NewMarkingDefinitionDataSource is a helper function to simplify the provider implementation.
+func NewMarkingDefinitionDataSource() datasource.DataSource {
+	return &markingDefinitionDataSource{}
+}
+
+// markingDefinitionDataSource is the data source implementation.
+type markingDefinitionDataSource struct {
+	data *openctiProviderData
+}
+
+// markingDefinitionDataSourceModel maps the data source schema data, shaped like markingDefinitionResourceModel.
+type markingDefinitionDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	StandardID     types.String `tfsdk:"standard_id"`
+	DefinitionType types.String `tfsdk:"definition_type"`
+	Definition     types.String `tfsdk:"definition"`
+	XOpenctiOrder  types.Int32  `tfsdk:"x_opencti_order"`
+	XOpenctiColor  types.String `tfsdk:"x_opencti_color"`
+}
+
+// markingDefinitionNode is a marking definition as returned by the markingDefinitionAttributes.
+type markingDefinitionNode struct {
+	ID             string `json:"id"`
+	StandardID     string `json:"standard_id"`
+	DefinitionType string `json:"definition_type"`
+	Definition     string `json:"definition"`
+	XOpenctiOrder  int32  `json:"x_opencti_order"`
+	XOpenctiColor  string `json:"x_opencti_color"`
+}
+
+// markingDefinitionAttributes are the GraphQL attributes of the marking definitions read by the data sources.
+const markingDefinitionAttributes = "id standard_id definition_type definition x_opencti_order x_opencti_color"
+
+// markingDefinitionDataAttributes returns the attributes of a marking definition, computed unless they are lookup keys.
+func markingDefinitionDataAttributes(lookup bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Optional:            lookup,
+			Computed:            true,
+			MarkdownDescription: "ID of the marking definition.",
+		},
+		"standard_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "STIX ID of the marking definition.",
+		},
+		"definition_type": schema.StringAttribute{
+			Optional:            lookup,
+			Computed:            true,
+			MarkdownDescription: "Type of the marking definition, e.g. `TLP` or `PAP`.",
+		},
+		"definition": schema.StringAttribute{
+			Optional:            lookup,
+			Computed:            true,
+			MarkdownDescription: "Definition of the marking definition, e.g. `TLP:GREEN`, as in the `allowed_marking` of the `opencti_group` resource.",
+		},
+		"x_opencti_order": schema.Int32Attribute{
+			Computed: true,
+		},
+		"x_opencti_color": schema.StringAttribute{
+			Computed: true,
+		},
+	}
+}
+
+// markingDefinitionData converts a marking definition read from opencti into the data source model.
+func markingDefinitionData(marking markingDefinitionNode) markingDefinitionDataSourceModel {
+	return markingDefinitionDataSourceModel{
+		ID:             types.StringValue(marking.ID),
+		StandardID:     types.StringValue(marking.StandardID),
+		DefinitionType: types.StringValue(marking.DefinitionType),
+		Definition:     types.StringValue(marking.Definition),
+		XOpenctiOrder:  types.Int32Value(marking.XOpenctiOrder),
+		XOpenctiColor:  types.StringValue(marking.XOpenctiColor),
+	}
+}
+
+// markingDefinitionDataSourceRequirement is the minimum platform supporting the marking definition lookup.
+var markingDefinitionDataSourceRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
+}
+
+// Metadata returns the data source type name.
+func (d *markingDefinitionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_marking_definition"
+}
+
+// Schema defines the schema for the data source.
+func (d *markingDefinitionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a marking definition by `definition_type` and `definition`, or by `id`, e.g. a built-in TLP or PAP marking.",
+		Attributes:          markingDefinitionDataAttributes(true),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *markingDefinitionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config markingDefinitionDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	byDefinition := !config.DefinitionType.IsNull() && !config.Definition.IsNull()
+
+	if config.ID.IsNull() == byDefinition || (!byDefinition && (!config.DefinitionType.IsNull() || !config.Definition.IsNull())) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("definition"),
+			"Invalid opencti marking definition lookup",
+			"Either the id attribute, or both the definition_type and definition attributes must be set.",
+		)
+
+		return
+	}
+
+	var marking *markingDefinitionNode
+
+	if byDefinition {
+		nodes, err := d.data.graphql.listNodes(ctx, "markingDefinitions", markingDefinitionAttributes, andFilters(
+			newFilter("definition_type", "eq", config.DefinitionType.ValueString()),
+			newFilter("definition", "eq", config.Definition.ValueString()),
+		))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error listing opencti marking definitions",
+				"Could not list marking definitions, unexpected error: "+err.Error(),
+			)
+
+			return
+		}
+
+		markings, err := decodeNodes[markingDefinitionNode](nodes)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error decoding opencti marking definitions",
+				"Could not decode marking definitions, unexpected error: "+err.Error(),
+			)
+
+			return
+		}
+
+		// The filters are not necessarily exact, e.g. case insensitive
+		for i := range markings {
+			if markings[i].DefinitionType == config.DefinitionType.ValueString() && markings[i].Definition == config.Definition.ValueString() {
+				marking = &markings[i]
+
+				break
+			}
+		}
+	} else {
+		var data struct {
+			MarkingDefinition *markingDefinitionNode `json:"markingDefinition"`
+		}
+
+		if err := d.data.graphql.Do(ctx, "query MarkingDefinition($id: String!) { markingDefinition(id: $id) { "+markingDefinitionAttributes+" } }", map[string]any{"id": config.ID.ValueString()}, &data); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading opencti marking definition", err.Error(),
+			)
+
+			return
+		}
+
+		marking = data.MarkingDefinition
+	}
+
+	if marking == nil {
+		resp.Diagnostics.AddError(
+			"opencti marking definition not found",
+			"No marking definition matches the id, or the definition_type and definition.",
+		)
+
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Marking definition read: %+v", *marking))
+
+	// The groups resolve the marking definitions by definition, spare them the lookup
+	d.data.cache.set(lookupMarking, marking.Definition, marking.ID)
+
+	state := markingDefinitionData(*marking)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *markingDefinitionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*openctiProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+// ValidateConfig checks that the platform supports the data source.
+func (d *markingDefinitionDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if d.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.data.platform.check(markingDefinitionDataSourceRequirement, "opencti_marking_definition")...)
+}
@@ -12,15 +12,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/weisshorn-cyd/gocti"
 	"github.com/weisshorn-cyd/gocti/entity"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &markingDefinitionResource{}
-	_ resource.ResourceWithConfigure   = &markingDefinitionResource{}
-	_ resource.ResourceWithImportState = &markingDefinitionResource{}
+	_ resource.Resource                   = &markingDefinitionResource{}
+	_ resource.ResourceWithConfigure      = &markingDefinitionResource{}
+	_ resource.ResourceWithImportState    = &markingDefinitionResource{}
+	_ resource.ResourceWithModifyPlan     = &markingDefinitionResource{}
+	_ resource.ResourceWithValidateConfig = &markingDefinitionResource{}
 )
 
 // NewmarkingDefinitionDefinitionResource is a helper function to simplify the provider implementation.
@@ -30,17 +31,24 @@ func NewMarkingDefinitionResource() resource.Resource {
 
 // markingDefinitionResource is the resource implementation.
 type markingDefinitionResource struct {
-	client *gocti.OpenCTIAPIClient
+	data *openctiProviderData
 }
 
 // markingDefinitionResourceModel maps the resource schema data.
 type markingDefinitionResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	DefinitionType types.String `tfsdk:"definition_type"`
-	Definition     types.String `tfsdk:"definition"`
-	XOpenctiOrder  types.Int32  `tfsdk:"x_opencti_order"`
-	XOpenctiColor  types.String `tfsdk:"x_opencti_color"`
-	LastUpdated    types.String `tfsdk:"last_updated"`
+	ID              types.String `tfsdk:"id"`
+	DefinitionType  types.String `tfsdk:"definition_type"`
+	Definition      types.String `tfsdk:"definition"`
+	XOpenctiOrder   types.Int32  `tfsdk:"x_opencti_order"`
+	XOpenctiColor   types.String `tfsdk:"x_opencti_color"`
+	ImpersonateUser types.String `tfsdk:"impersonate_user"`
+	Timeouts        types.Object `tfsdk:"timeouts"`
+	LastUpdated     types.String `tfsdk:"last_updated"`
+}
+
+// markingDefinitionRequirement is the minimum platform supporting the marking definitions.
+var markingDefinitionRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
 }
 
 // Metadata returns the resource type name.
@@ -73,12 +81,19 @@ func (r *markingDefinitionResource) Schema(_ context.Context, _ resource.SchemaR
 			"x_opencti_color": schema.StringAttribute{
 				Required: true,
 			},
+			"impersonate_user": impersonateUserAttribute(),
+			"timeouts":         timeoutsAttribute(),
 		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *markingDefinitionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "create", "opencti_marking_definition") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan markingDefinitionResourceModel
 
@@ -89,10 +104,20 @@ func (r *markingDefinitionResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "create", "opencti_marking_definition")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, "Creating marking definition")
 
 	// Create new markingDefinition
-	createdMarking, err := r.client.CreateMarkingDefinition(ctx, "", entity.MarkingDefinitionAddInput{
+	createdMarking, err := r.data.client.CreateMarkingDefinition(ctx, "", entity.MarkingDefinitionAddInput{
 		DefinitionType: plan.DefinitionType.ValueString(),
 		Definition:     plan.Definition.ValueString(),
 		XOpenctiOrder:  int(plan.XOpenctiOrder.ValueInt32()),
@@ -109,6 +134,8 @@ func (r *markingDefinitionResource) Create(ctx context.Context, req resource.Cre
 
 	tflog.Debug(ctx, fmt.Sprintf("Marking definition created: %+v", createdMarking))
 
+	r.data.cache.set(lookupMarking, createdMarking.Definition, createdMarking.ID)
+
 	plan.ID = types.StringValue(createdMarking.ID)
 	plan.DefinitionType = types.StringValue(createdMarking.DefinitionType)
 	plan.Definition = types.StringValue(createdMarking.Definition)
@@ -137,8 +164,12 @@ func (r *markingDefinitionResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "read", "opencti_marking_definition")
+
 	// Get marking definitions from opencti
-	marking, err := r.client.ReadMarkingDefinition(ctx, "id definition_type definition x_opencti_order x_opencti_color", state.ID.ValueString())
+	marking, err := r.data.client.ReadMarkingDefinition(ctx, "id definition_type definition x_opencti_order x_opencti_color", state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading opencti marking definition",
@@ -165,12 +196,30 @@ func (r *markingDefinitionResource) Read(ctx context.Context, req resource.ReadR
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *markingDefinitionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Retrieve values from plan
-	var plan markingDefinitionResourceModel
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "update", "opencti_marking_definition") {
+		return
+	}
+
+	// Retrieve values from plan and state
+	var plan, state markingDefinitionResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "update", "opencti_marking_definition")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -178,7 +227,7 @@ func (r *markingDefinitionResource) Update(ctx context.Context, req resource.Upd
 	tflog.Info(ctx, "Updating marking definition")
 
 	// Create new markingDefinition
-	createdMarking, err := r.client.CreateMarkingDefinition(ctx, "", entity.MarkingDefinitionAddInput{
+	createdMarking, err := r.data.client.CreateMarkingDefinition(ctx, "", entity.MarkingDefinitionAddInput{
 		DefinitionType: plan.DefinitionType.ValueString(),
 		Definition:     plan.Definition.ValueString(),
 		XOpenctiOrder:  int(plan.XOpenctiOrder.ValueInt32()),
@@ -195,6 +244,10 @@ func (r *markingDefinitionResource) Update(ctx context.Context, req resource.Upd
 
 	tflog.Debug(ctx, fmt.Sprintf("Marking definition created: %+v", createdMarking))
 
+	// The previous definition does not resolve to the marking definition anymore
+	r.data.cache.remove(lookupMarking, state.Definition.ValueString())
+	r.data.cache.set(lookupMarking, createdMarking.Definition, createdMarking.ID)
+
 	plan.ID = types.StringValue(createdMarking.ID)
 	plan.DefinitionType = types.StringValue(createdMarking.DefinitionType)
 	plan.Definition = types.StringValue(createdMarking.Definition)
@@ -213,6 +266,11 @@ func (r *markingDefinitionResource) Update(ctx context.Context, req resource.Upd
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *markingDefinitionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "delete", "opencti_marking_definition") {
+		return
+	}
+
 	// Retrieve values from state
 	var state markingDefinitionResourceModel
 
@@ -223,7 +281,17 @@ func (r *markingDefinitionResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
-	if _, err := r.client.DeleteMarkingDefinition(ctx, state.ID.ValueString()); err != nil {
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "delete", "opencti_marking_definition")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, state.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.data.client.DeleteMarkingDefinition(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting OpenCTI Marking Definition",
 			"Could not delete marking definition, unexpected error: "+err.Error(),
@@ -231,6 +299,8 @@ func (r *markingDefinitionResource) Delete(ctx context.Context, req resource.Del
 
 		return
 	}
+
+	r.data.cache.remove(lookupMarking, state.Definition.ValueString())
 }
 
 // Configure adds the provider configured client to the resource.
@@ -241,17 +311,37 @@ func (r *markingDefinitionResource) Configure(_ context.Context, req resource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*gocti.OpenCTIAPIClient)
+	data, ok := req.ProviderData.(*openctiProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *gocti.OpenCTIAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.data = data
+}
+
+// ValidateConfig checks that the platform supports the resource.
+func (r *markingDefinitionResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.platform.check(markingDefinitionRequirement, "opencti_marking_definition")...)
+}
+
+// ModifyPlan checks that the provider allows the planned change.
+func (r *markingDefinitionResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the provider is not configured, e.g. when its configuration is deferred
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.checkReadOnlyPlan(req.State, resp.Plan, "opencti_marking_definition")...)
 }
 
 func (r *markingDefinitionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
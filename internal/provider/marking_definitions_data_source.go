@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &markingDefinitionsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &markingDefinitionsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &markingDefinitionsDataSource{}
+)
+
+// NewMarkingDefinitionsDataSource is a helper function to simplify the provider implementation.
+func NewMarkingDefinitionsDataSource() datasource.DataSource {
+	return &markingDefinitionsDataSource{}
+}
+
+// markingDefinitionsDataSource is the data source implementation.
+type markingDefinitionsDataSource struct {
+	data *openctiProviderData
+}
+
+// markingDefinitionsDataSourceModel maps the data source schema data.
+type markingDefinitionsDataSourceModel struct {
+	DefinitionType     types.String                       `tfsdk:"definition_type"`
+	MarkingDefinitions []markingDefinitionDataSourceModel `tfsdk:"marking_definitions"`
+}
+
+// markingDefinitionsRequirement is the minimum platform supporting the marking definition listing.
+var markingDefinitionsRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
+}
+
+// Metadata returns the data source type name.
+func (d *markingDefinitionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_marking_definitions"
+}
+
+// Schema defines the schema for the data source.
+func (d *markingDefinitionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the marking definitions of the platform.",
+		Attributes: map[string]schema.Attribute{
+			"definition_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Type of the marking definitions, e.g. `TLP` or `PAP`, filtered by opencti.",
+			},
+			"marking_definitions": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Marking definitions of the `definition_type`, sorted by type, order and definition.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: markingDefinitionDataAttributes(false),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *markingDefinitionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state markingDefinitionsDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filters map[string]any
+	if !state.DefinitionType.IsNull() {
+		filters = eqFilter("definition_type", state.DefinitionType.ValueString())
+	}
+
+	nodes, err := d.data.graphql.listNodes(ctx, "markingDefinitions", markingDefinitionAttributes, filters)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing opencti marking definitions",
+			"Could not list marking definitions, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	markings, err := decodeNodes[markingDefinitionNode](nodes)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error decoding opencti marking definitions",
+			"Could not decode marking definitions, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	state.MarkingDefinitions = []markingDefinitionDataSourceModel{}
+
+	for _, marking := range markings {
+		// The filters are not necessarily exact, e.g. case insensitive
+		if !state.DefinitionType.IsNull() && marking.DefinitionType != state.DefinitionType.ValueString() {
+			continue
+		}
+
+		// The groups resolve the marking definitions by definition, spare them the lookup
+		d.data.cache.set(lookupMarking, marking.Definition, marking.ID)
+
+		state.MarkingDefinitions = append(state.MarkingDefinitions, markingDefinitionData(marking))
+	}
+
+	sort.Slice(state.MarkingDefinitions, func(i, j int) bool {
+		a, b := state.MarkingDefinitions[i], state.MarkingDefinitions[j]
+
+		if a.DefinitionType.ValueString() != b.DefinitionType.ValueString() {
+			return a.DefinitionType.ValueString() < b.DefinitionType.ValueString()
+		}
+
+		if a.XOpenctiOrder.ValueInt32() != b.XOpenctiOrder.ValueInt32() {
+			return a.XOpenctiOrder.ValueInt32() < b.XOpenctiOrder.ValueInt32()
+		}
+
+		return a.Definition.ValueString() < b.Definition.ValueString()
+	})
+
+	tflog.Debug(ctx, fmt.Sprintf("Marking definitions found: %d", len(state.MarkingDefinitions)))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *markingDefinitionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*openctiProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+// ValidateConfig checks that the platform supports the data source.
+func (d *markingDefinitionsDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if d.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.data.platform.check(markingDefinitionsRequirement, "opencti_marking_definitions")...)
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+	"strings"
+)
+
+// managedName returns the name of a managed object on opencti, with the prefix and suffix of the provider.
+func (d *openctiProviderData) managedName(name string) string {
+	return d.namePrefix + name + d.nameSuffix
+}
+
+// stateName returns the name of an object as kept in the state, without the prefix and suffix of the provider.
+// The names of the objects which are not managed with the prefix and suffix are returned unchanged.
+func (d *openctiProviderData) stateName(name string) string {
+	if d.namePrefix == "" && d.nameSuffix == "" {
+		return name
+	}
+
+	if !strings.HasPrefix(name, d.namePrefix) || !strings.HasSuffix(name, d.nameSuffix) || len(name) < len(d.namePrefix)+len(d.nameSuffix) {
+		return name
+	}
+
+	return name[len(d.namePrefix) : len(name)-len(d.nameSuffix)]
+}
+
+// lookupNamedID returns the ID of a group or role referenced by name. The object with the prefixed
+// name, managed by the provider, is preferred over the one with the bare name, e.g. a built-in role.
+func (d *openctiProviderData) lookupNamedID(ctx context.Context, kind lookupKind, name string) (string, bool, error) {
+	if managed := d.managedName(name); managed != name {
+		id, found, err := d.lookupID(ctx, kind, managed)
+		if err != nil || found {
+			return id, found, err
+		}
+	}
+
+	return d.lookupID(ctx, kind, name)
+}
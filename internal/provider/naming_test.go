@@ -0,0 +1,72 @@
+package provider
+
+import "testing"
+
+func TestManagedName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		prefix string
+		suffix string
+		object string
+		want   string
+	}{
+		{name: "no prefix nor suffix", object: "SOC", want: "SOC"},
+		{name: "prefix", prefix: "test-", object: "SOC", want: "test-SOC"},
+		{name: "suffix", suffix: " (test)", object: "SOC", want: "SOC (test)"},
+		{name: "prefix and suffix", prefix: "[", suffix: "]", object: "SOC", want: "[SOC]"},
+		{name: "empty name", prefix: "test-", object: "", want: "test-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &openctiProviderData{namePrefix: tt.prefix, nameSuffix: tt.suffix}
+
+			if got := d.managedName(tt.object); got != tt.want {
+				t.Errorf("managedName(%q) = %q, want %q", tt.object, got, tt.want)
+			}
+
+			// The managed names are kept in the state without the prefix and suffix
+			if got := d.stateName(d.managedName(tt.object)); got != tt.object {
+				t.Errorf("stateName(managedName(%q)) = %q, want %q", tt.object, got, tt.object)
+			}
+		})
+	}
+}
+
+func TestStateName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		prefix string
+		suffix string
+		object string
+		want   string
+	}{
+		{name: "no prefix nor suffix", object: "test-SOC", want: "test-SOC"},
+		{name: "prefixed", prefix: "test-", object: "test-SOC", want: "SOC"},
+		{name: "suffixed", suffix: "-test", object: "SOC-test", want: "SOC"},
+		{name: "prefixed and suffixed", prefix: "test-", suffix: "-v2", object: "test-SOC-v2", want: "SOC"},
+		{name: "not prefixed", prefix: "test-", object: "Administrators", want: "Administrators"},
+		{name: "prefix without suffix", prefix: "test-", suffix: "-v2", object: "test-SOC", want: "test-SOC"},
+		{name: "suffix without prefix", prefix: "test-", suffix: "-v2", object: "SOC-v2", want: "SOC-v2"},
+		{name: "overlapping prefix and suffix", prefix: "ab", suffix: "ba", object: "aba", want: "aba"},
+		{name: "only prefix and suffix", prefix: "[", suffix: "]", object: "[]", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &openctiProviderData{namePrefix: tt.prefix, nameSuffix: tt.suffix}
+
+			if got := d.stateName(tt.object); got != tt.want {
+				t.Errorf("stateName(%q) = %q, want %q", tt.object, got, tt.want)
+			}
+		})
+	}
+}
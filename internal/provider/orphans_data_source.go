@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &orphansDataSource{}
+	_ datasource.DataSourceWithConfigure      = &orphansDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &orphansDataSource{}
+)
+
+// NewOrphansDataSource is a helper function to simplify the provider implementation.
+func NewOrphansDataSource() datasource.DataSource {
+	return &orphansDataSource{}
+}
+
+// orphansDataSource is the data source implementation.
+type orphansDataSource struct {
+	data *openctiProviderData
+}
+
+// orphansDataSourceModel maps the data source schema data.
+type orphansDataSourceModel struct {
+	ManagedIDs types.Set     `tfsdk:"managed_ids"`
+	Types      types.Set     `tfsdk:"types"`
+	Orphans    []orphanModel `tfsdk:"orphans"`
+}
+
+// orphanModel maps an orphan object.
+type orphanModel struct {
+	ID   types.String `tfsdk:"id"`
+	Type types.String `tfsdk:"type"`
+	Name types.String `tfsdk:"name"`
+}
+
+// markedField is the GraphQL field listing the objects of a resource type which can carry the
+// ownership marker, at the end of their description or of their name for the objects without one.
+type markedField struct {
+	field  string
+	inName bool
+}
+
+// markedFields are the fields listing the marked objects, by resource type.
+var markedFields = map[string]markedField{
+	"opencti_case_template":   {field: "caseTemplates"},
+	"opencti_group":           {field: "groups"},
+	"opencti_role":            {field: "roles"},
+	"opencti_status_template": {field: "statusTemplates", inName: true},
+	"opencti_task_template":   {field: "taskTemplates"},
+	"opencti_vocabulary":      {field: "vocabularies"},
+}
+
+// orphansRequirement is the minimum platform supporting the search of the marked objects.
+var orphansRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
+}
+
+// Metadata returns the data source type name.
+func (d *orphansDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_orphans"
+}
+
+// Schema defines the schema for the data source.
+func (d *orphansDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Objects carrying the `ownership_marker` of the provider which are not managed anymore, e.g. left behind by a removed resource or a lost state.",
+		Attributes: map[string]schema.Attribute{
+			"managed_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "IDs of the objects which are still managed, typically the `id` of the resources of the configuration.",
+			},
+			"types": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Resource types of the objects to look for, among `opencti_case_template`, `opencti_group`, `opencti_role`, `opencti_status_template`, `opencti_task_template` and `opencti_vocabulary` (defaults to all of them).",
+			},
+			"orphans": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Marked objects whose ID is not in `managed_ids`, sorted by type and name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Resource type of the object.",
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *orphansDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state orphansDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.data.ownershipMarker == "" {
+		resp.Diagnostics.AddError(
+			"Missing opencti ownership marker",
+			"The orphans can only be found when the ownership_marker setting of the provider is set.",
+		)
+
+		return
+	}
+
+	var managedIDs, resourceTypes []string
+
+	resp.Diagnostics.Append(state.ManagedIDs.ElementsAs(ctx, &managedIDs, false)...)
+
+	if state.Types.IsNull() {
+		for resourceType := range markedFields {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	} else {
+		resp.Diagnostics.Append(state.Types.ElementsAs(ctx, &resourceTypes, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed := map[string]bool{}
+	for _, id := range managedIDs {
+		managed[id] = true
+	}
+
+	state.Orphans = []orphanModel{}
+
+	for _, resourceType := range resourceTypes {
+		marked, ok := markedFields[resourceType]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("types"),
+				"Unsupported resource type",
+				fmt.Sprintf("The %s objects cannot carry the ownership marker.", resourceType),
+			)
+
+			return
+		}
+
+		attributes := "id name description"
+		if marked.inName {
+			attributes = "id name"
+		}
+
+		nodes, err := d.data.graphql.listNodes(ctx, marked.field, attributes, nil)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error listing opencti objects",
+				"Could not list the "+resourceType+" objects, unexpected error: "+err.Error(),
+			)
+
+			return
+		}
+
+		for _, raw := range nodes {
+			var node struct {
+				ID          string `json:"id"`
+				Name        string `json:"name"`
+				Description string `json:"description"`
+			}
+
+			if err := json.Unmarshal(raw, &node); err != nil {
+				resp.Diagnostics.AddError(
+					"Error decoding opencti object",
+					"Could not decode a "+resourceType+" object, unexpected error: "+err.Error(),
+				)
+
+				return
+			}
+
+			name, isMarked := node.Name, d.data.isMarked(node.Description)
+			if marked.inName {
+				name, isMarked = d.data.unmarkName(node.Name), d.data.isMarkedName(node.Name)
+			}
+
+			if !isMarked || managed[node.ID] {
+				continue
+			}
+
+			state.Orphans = append(state.Orphans, orphanModel{
+				ID:   types.StringValue(node.ID),
+				Type: types.StringValue(resourceType),
+				Name: types.StringValue(d.data.stateName(name)),
+			})
+		}
+	}
+
+	sort.Slice(state.Orphans, func(i, j int) bool {
+		if state.Orphans[i].Type.ValueString() != state.Orphans[j].Type.ValueString() {
+			return state.Orphans[i].Type.ValueString() < state.Orphans[j].Type.ValueString()
+		}
+
+		return state.Orphans[i].Name.ValueString() < state.Orphans[j].Name.ValueString()
+	})
+
+	tflog.Debug(ctx, fmt.Sprintf("Orphans found: %d", len(state.Orphans)))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *orphansDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*openctiProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+// ValidateConfig checks that the platform supports the data source.
+func (d *orphansDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if d.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.data.platform.check(orphansRequirement, "opencti_orphans")...)
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"strings"
+)
+
+// ownershipSeparator separates the ownership marker from the description of an object.
+const ownershipSeparator = "\n\n"
+
+// fieldPatchMutations are the GraphQL mutations editing a field of the objects carrying the
+// ownership marker, by resource type.
+var fieldPatchMutations = map[string]string{
+	"opencti_case_template":   "mutation CaseTemplatePatch($id: ID!, $input: [EditInput!]!) { caseTemplateFieldPatch(id: $id, input: $input) { id } }",
+	"opencti_group":           "mutation GroupPatch($id: ID!, $input: [EditInput!]!) { groupEdit(id: $id) { fieldPatch(input: $input) { id } } }",
+	"opencti_role":            "mutation RolePatch($id: ID!, $input: [EditInput!]!) { roleEdit(id: $id) { fieldPatch(input: $input) { id } } }",
+	"opencti_status_template": "mutation StatusTemplatePatch($id: ID!, $input: [EditInput!]!) { statusTemplateFieldPatch(id: $id, input: $input) { id } }",
+}
+
+// markDescription returns the description of a managed object on opencti, ending with the ownership marker.
+func (d *openctiProviderData) markDescription(description string) string {
+	if d.ownershipMarker == "" {
+		return description
+	}
+
+	if description == "" {
+		return d.ownershipMarker
+	}
+
+	return description + ownershipSeparator + d.ownershipMarker
+}
+
+// unmarkDescription returns the description of an object as kept in the state, without the ownership marker.
+func (d *openctiProviderData) unmarkDescription(description string) string {
+	if d.ownershipMarker == "" || !strings.HasSuffix(description, d.ownershipMarker) {
+		return description
+	}
+
+	description = strings.TrimSuffix(description, d.ownershipMarker)
+
+	return strings.TrimSuffix(description, ownershipSeparator)
+}
+
+// isMarked tells whether the description of an object carries the ownership marker.
+func (d *openctiProviderData) isMarked(description string) bool {
+	return d.ownershipMarker != "" && strings.HasSuffix(description, d.ownershipMarker)
+}
+
+// markName returns the name of a managed object without description on opencti, such as a status
+// template, ending with the ownership marker between brackets.
+func (d *openctiProviderData) markName(name string) string {
+	if d.ownershipMarker == "" {
+		return name
+	}
+
+	return name + " [" + d.ownershipMarker + "]"
+}
+
+// unmarkName returns the name of an object without description as kept in the state, without the ownership marker.
+func (d *openctiProviderData) unmarkName(name string) string {
+	if !d.isMarkedName(name) {
+		return name
+	}
+
+	return strings.TrimSuffix(name, " ["+d.ownershipMarker+"]")
+}
+
+// isMarkedName tells whether the name of an object without description carries the ownership marker.
+func (d *openctiProviderData) isMarkedName(name string) bool {
+	return d.ownershipMarker != "" && strings.HasSuffix(name, " ["+d.ownershipMarker+"]")
+}
+
+// restamp sets a field of an object to its stamped value when they differ, e.g. when the object was
+// created before the ownership marker was set. The objects are stamped when their resource is updated.
+func (d *openctiProviderData) restamp(ctx context.Context, resourceType, id, field, current, stamped string) error {
+	if current == stamped {
+		return nil
+	}
+
+	return d.graphql.Do(ctx, fieldPatchMutations[resourceType], map[string]any{
+		"id": id,
+		"input": []map[string]any{
+			{"key": field, "value": []string{stamped}},
+		},
+	}, nil)
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// minPlatformVersion is the oldest opencti version supported by the provider, which every
+// resource and data source requires at least.
+const minPlatformVersion = "6.0.0"
+
+// editionEnterprise is the edition of the resources and data sources needing an enterprise license.
+const editionEnterprise = "enterprise"
+
+// platformInfo describes the opencti platform the provider is connected to.
+type platformInfo struct {
+	Version    string
+	Enterprise bool
+	// EditionKnown is false when the edition could not be detected
+	EditionKnown bool
+}
+
+// platformRequirement is the minimum platform needed by a resource or a data source.
+type platformRequirement struct {
+	MinVersion string
+	// Edition is empty when the community edition is enough
+	Edition string
+}
+
+// detectPlatform queries the version and the edition of the opencti platform.
+func detectPlatform(ctx context.Context, client *graphqlClient) (*platformInfo, error) {
+	var about struct {
+		About struct {
+			Version string `json:"version"`
+		} `json:"about"`
+	}
+
+	if err := client.Do(ctx, "query { about { version } }", nil, &about); err != nil {
+		return nil, fmt.Errorf("querying opencti version: %w", err)
+	}
+
+	platform := &platformInfo{
+		Version: about.About.Version,
+	}
+
+	var settings struct {
+		Settings struct {
+			PlatformEnterpriseEdition struct {
+				LicenseValidated bool `json:"license_validated"`
+			} `json:"platform_enterprise_edition"`
+		} `json:"settings"`
+	}
+
+	// The edition is not exposed by every version, the requirements on it are not checked then
+	if err := client.Do(ctx, "query { settings { platform_enterprise_edition { license_validated } } }", nil, &settings); err != nil {
+		tflog.Warn(ctx, "Unable to detect the opencti edition, assuming community edition", map[string]any{"error": err.Error()})
+	} else {
+		platform.Enterprise = settings.Settings.PlatformEnterpriseEdition.LicenseValidated
+		platform.EditionKnown = true
+	}
+
+	return platform, nil
+}
+
+// edition returns the name of the edition of the platform.
+func (p *platformInfo) edition() string {
+	if !p.EditionKnown {
+		return "unknown"
+	}
+
+	if p.Enterprise {
+		return editionEnterprise
+	}
+
+	return "community"
+}
+
+// knownVersion tells whether the version of the platform could be parsed.
+func (p *platformInfo) knownVersion() bool {
+	return len(versionParts(p.Version)) > 0
+}
+
+// check returns an error diagnostic when the platform does not meet the requirement of a resource
+// or a data source. Nothing is checked when the platform is unknown, e.g. when the health check is
+// skipped, nor against a version or an edition which could not be detected, the provider warns
+// about them when it is configured.
+func (p *platformInfo) check(requirement platformRequirement, typeName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if p == nil {
+		return diags
+	}
+
+	if p.knownVersion() && requirement.MinVersion != "" && compareVersions(p.Version, requirement.MinVersion) < 0 {
+		diags.AddError(
+			"Unsupported opencti version",
+			fmt.Sprintf("%s requires opencti %s or later, but the platform runs opencti %s.", typeName, requirement.MinVersion, p.Version),
+		)
+	}
+
+	if p.EditionKnown && requirement.Edition == editionEnterprise && !p.Enterprise {
+		diags.AddError(
+			"Unsupported opencti edition",
+			fmt.Sprintf("%s requires the opencti enterprise edition, but the platform runs the community edition.", typeName),
+		)
+	}
+
+	return diags
+}
+
+// compareVersions compares two dotted versions such as 6.8.11, ignoring any pre-release suffix.
+// It returns -1, 0 or 1 when a is respectively lower, equal or greater than b.
+func compareVersions(a, b string) int {
+	partsA := versionParts(a)
+	partsB := versionParts(b)
+
+	for i := range max(len(partsA), len(partsB)) {
+		var va, vb int
+
+		if i < len(partsA) {
+			va = partsA[i]
+		}
+
+		if i < len(partsB) {
+			vb = partsB[i]
+		}
+
+		switch {
+		case va < vb:
+			return -1
+		case va > vb:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// versionParts returns the numeric parts of a dotted version.
+func versionParts(version string) []int {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+ "); i >= 0 {
+		version = version[:i]
+	}
+
+	parts := []int{}
+
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+
+		parts = append(parts, n)
+	}
+
+	return parts
+}
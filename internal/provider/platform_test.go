@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestPlatformCheck(t *testing.T) {
+	t.Parallel()
+
+	community := &platformInfo{Version: "6.8.11", EditionKnown: true}
+	enterprise := &platformInfo{Version: "6.8.11", Enterprise: true, EditionKnown: true}
+
+	tests := []struct {
+		name        string
+		platform    *platformInfo
+		requirement platformRequirement
+		wantError   bool
+	}{
+		{name: "unknown platform", platform: nil, requirement: platformRequirement{MinVersion: "7.0.0", Edition: editionEnterprise}, wantError: false},
+		{name: "recent version", platform: community, requirement: platformRequirement{MinVersion: minPlatformVersion}, wantError: false},
+		{name: "same version", platform: community, requirement: platformRequirement{MinVersion: "6.8.11"}, wantError: false},
+		{name: "old version", platform: &platformInfo{Version: "5.12.32", EditionKnown: true}, requirement: platformRequirement{MinVersion: minPlatformVersion}, wantError: true},
+		{name: "pre-release of an old version", platform: &platformInfo{Version: "5.12.0-rc1"}, requirement: platformRequirement{MinVersion: minPlatformVersion}, wantError: true},
+		{name: "unknown version", platform: &platformInfo{Version: "dev"}, requirement: platformRequirement{MinVersion: minPlatformVersion}, wantError: false},
+		{name: "community edition", platform: community, requirement: platformRequirement{Edition: editionEnterprise}, wantError: true},
+		{name: "enterprise edition", platform: enterprise, requirement: platformRequirement{Edition: editionEnterprise}, wantError: false},
+		{name: "unknown edition", platform: &platformInfo{Version: "6.8.11"}, requirement: platformRequirement{Edition: editionEnterprise}, wantError: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diags := tt.platform.check(tt.requirement, "opencti_test"); diags.HasError() != tt.wantError {
+				t.Errorf("check(%+v) errors = %v, want error %t", tt.requirement, diags.Errors(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{name: "equal", a: "6.0.0", b: "6.0.0", want: 0},
+		{name: "lower patch", a: "6.8.10", b: "6.8.11", want: -1},
+		{name: "greater minor", a: "6.10.0", b: "6.9.0", want: 1},
+		{name: "missing parts", a: "6", b: "6.0.0", want: 0},
+		{name: "prefix and suffix", a: "v6.1.0-rc1", b: "6.1.0", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := compareVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+// Every resource and data source checks its platform requirement when its configuration is validated.
+func TestPlatformRequirementsChecked(t *testing.T) {
+	t.Parallel()
+
+	p := &openctiProvider{}
+
+	for _, newResource := range p.Resources(t.Context()) {
+		r := newResource()
+
+		if _, ok := r.(resource.ResourceWithValidateConfig); !ok {
+			t.Errorf("%T does not check its platform requirement", r)
+		}
+	}
+
+	for _, newDataSource := range p.DataSources(t.Context()) {
+		d := newDataSource()
+
+		if _, ok := d.(datasource.DataSourceWithValidateConfig); !ok {
+			t.Errorf("%T does not check its platform requirement", d)
+		}
+	}
+}
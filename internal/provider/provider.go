@@ -3,7 +3,9 @@ package provider
 import (
 	"context"
 	"log/slog"
+	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -23,8 +25,60 @@ var (
 
 // openctiProviderModel maps provider schema data to a Go type.
 type openctiProviderModel struct {
-	URL   types.String `tfsdk:"url"`
-	Token types.String `tfsdk:"token"`
+	URL                   types.String  `tfsdk:"url"`
+	Token                 types.String  `tfsdk:"token"`
+	TokenFile             types.String  `tfsdk:"token_file"`
+	TokenCommand          types.List    `tfsdk:"token_command"`
+	Username              types.String  `tfsdk:"username"`
+	Password              types.String  `tfsdk:"password"`
+	CACert                types.String  `tfsdk:"ca_cert"`
+	CACertFile            types.String  `tfsdk:"ca_cert_file"`
+	ClientCert            types.String  `tfsdk:"client_cert"`
+	ClientCertFile        types.String  `tfsdk:"client_cert_file"`
+	ClientKey             types.String  `tfsdk:"client_key"`
+	ClientKeyFile         types.String  `tfsdk:"client_key_file"`
+	InsecureSkipVerify    types.Bool    `tfsdk:"insecure_skip_verify"`
+	Headers               types.Map     `tfsdk:"headers"`
+	SensitiveHeaders      types.Map     `tfsdk:"sensitive_headers"`
+	ProxyURL              types.String  `tfsdk:"proxy_url"`
+	NoProxy               types.String  `tfsdk:"no_proxy"`
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	MinBackoff            types.String  `tfsdk:"min_backoff"`
+	MaxBackoff            types.String  `tfsdk:"max_backoff"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	RequestTimeout        types.String  `tfsdk:"request_timeout"`
+	WaitForReady          types.Bool    `tfsdk:"wait_for_ready"`
+	ReadyTimeout          types.String  `tfsdk:"ready_timeout"`
+	SkipHealthCheck       types.Bool    `tfsdk:"skip_health_check"`
+	LogLevel              types.String  `tfsdk:"log_level"`
+	ImpersonateUser       types.String  `tfsdk:"impersonate_user"`
+	ReadOnly              types.Bool    `tfsdk:"read_only"`
+	Defaults              types.Object  `tfsdk:"defaults"`
+	NamePrefix            types.String  `tfsdk:"name_prefix"`
+	NameSuffix            types.String  `tfsdk:"name_suffix"`
+	OwnershipMarker       types.String  `tfsdk:"ownership_marker"`
+}
+
+// openctiProviderData is made available to the data sources and resources
+// during their Configure.
+type openctiProviderData struct {
+	client *gocti.OpenCTIAPIClient
+	// graphql sends the requests not covered by gocti, through the same transport
+	graphql *graphqlClient
+	// platform is nil when the health check is skipped
+	platform *platformInfo
+	// cache holds the IDs of the objects referenced by name by the resources
+	cache *lookupCache
+	// readOnly forbids any change of the resources
+	readOnly bool
+	// defaults are merged into the plans of the resources
+	defaults resourceDefaults
+	// namePrefix and nameSuffix are added to the names of the managed objects on opencti
+	namePrefix string
+	nameSuffix string
+	// ownershipMarker ends the descriptions of the managed objects
+	ownershipMarker string
 }
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -61,6 +115,133 @@ func (p *openctiProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:  true,
 				Sensitive: true,
 			},
+			"token_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path to a file containing the opencti token. Conflicts with `token` and `token_command`. Can also be set with the `OPENCTI_TOKEN_FILE` environment variable.",
+			},
+			"token_command": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Command, given as the program followed by its arguments, whose standard output is used as the opencti token. Conflicts with `token` and `token_file`.",
+			},
+			"username": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Email of the user to log in with when no token is set, the API token of the user is then retrieved with the opencti login. When set in the configuration, the `OPENCTI_TOKEN` and `OPENCTI_TOKEN_FILE` environment variables are ignored. Can also be set with the `OPENCTI_ADMIN_EMAIL` environment variable.",
+			},
+			"password": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Password of the user to log in with when no token is set. Can also be set with the `OPENCTI_ADMIN_PASSWORD` environment variable.",
+			},
+			"ca_cert": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "PEM encoded CA bundle used to verify the opencti server certificate. Can also be set with the `OPENCTI_CA_CERT` environment variable.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path to a PEM encoded CA bundle used to verify the opencti server certificate. Can also be set with the `OPENCTI_CA_CERT_FILE` environment variable.",
+			},
+			"client_cert": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS. Can also be set with the `OPENCTI_CLIENT_CERT` environment variable.",
+			},
+			"client_cert_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path to a PEM encoded client certificate for mutual TLS. Can also be set with the `OPENCTI_CLIENT_CERT_FILE` environment variable.",
+			},
+			"client_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "PEM encoded private key of the client certificate. Can also be set with the `OPENCTI_CLIENT_KEY` environment variable.",
+			},
+			"client_key_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path to the PEM encoded private key of the client certificate. Can also be set with the `OPENCTI_CLIENT_KEY_FILE` environment variable.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Disable the verification of the opencti server certificate. Only meant for lab environments. Can also be set with the `OPENCTI_INSECURE_SKIP_VERIFY` environment variable.",
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Additional HTTP headers sent with every request to opencti.",
+			},
+			"sensitive_headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Additional HTTP headers sent with every request to opencti, whose values are masked in the logs.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "URL of the HTTP(S) proxy used to reach opencti. Can also be set with the `OPENCTI_PROXY_URL` environment variable. The standard `HTTP_PROXY` and `HTTPS_PROXY` environment variables are used when not set.",
+			},
+			"no_proxy": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comma-separated list of hosts which bypass `proxy_url`. Can also be set with the `OPENCTI_NO_PROXY` environment variable.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of retries of a request failing with a transient error (defaults to 3, 0 disables the retries). The mutations are only retried when opencti provably did not apply them, e.g. when the connection failed or the object was locked. Can also be set with the `OPENCTI_MAX_RETRIES` environment variable.",
+			},
+			"min_backoff": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Initial wait duration between two retries, doubled at each retry (defaults to `1s`). Can also be set with the `OPENCTI_MIN_BACKOFF` environment variable.",
+			},
+			"max_backoff": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum wait duration between two retries (defaults to `30s`). Can also be set with the `OPENCTI_MAX_BACKOFF` environment variable.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of requests sent concurrently to opencti, shared by all the resources and data sources (defaults to 0, no limit). Can also be set with the `OPENCTI_MAX_CONCURRENT_REQUESTS` environment variable.",
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of requests sent to opencti per second, shared by all the resources and data sources (defaults to 0, no limit). Can also be set with the `OPENCTI_REQUESTS_PER_SECOND` environment variable.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum duration of a single attempt of a request to opencti, including the read of its response (defaults to `10s`, `0s` disables it). The waits for `max_concurrent_requests`, `requests_per_second` and between the retries are not included, they are bounded by the timeouts of the resources. Can also be set with the `OPENCTI_REQUEST_TIMEOUT` environment variable.",
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Poll the opencti health check, and the login with `username` and `password`, until the platform is ready instead of failing at once. Can also be set with the `OPENCTI_WAIT_FOR_READY` environment variable.",
+			},
+			"ready_timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum duration to wait for the platform to be ready when `wait_for_ready` is set (defaults to `5m`). Can also be set with the `OPENCTI_READY_TIMEOUT` environment variable.",
+			},
+			"skip_health_check": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Do not check the opencti health when configuring the provider, e.g. for offline `terraform validate` or `terraform plan -refresh=false` runs. Conflicts with `wait_for_ready`. Can also be set with the `OPENCTI_SKIP_HEALTH_CHECK` environment variable.",
+			},
+			"log_level": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Minimum level of the gocti client logs, one of `trace`, `debug`, `info`, `warn` or `error` (defaults to `info`). The logs are written to the `gocti` subsystem of the provider logs, filtered by `TF_LOG_PROVIDER_OPENCTI` or `TF_LOG_PROVIDER_OPENCTI_GOCTI`. Can also be set with the `OPENCTI_LOG_LEVEL` environment variable.",
+			},
+			"impersonate_user": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ID or email of the user on behalf of whom the requests are made, through the opencti applicant header. The user of the token needs the permission to impersonate other users. An email is resolved when the provider is configured, or by the first request when `skip_health_check` is set. Can be overridden by the `impersonate_user` attribute of the resources. Can also be set with the `OPENCTI_IMPERSONATE_USER` environment variable.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Forbid any change of the resources: the plans fail when a change is planned and no mutation is ever sent to opencti, e.g. to detect drift with a read-only token. Can also be set with the `OPENCTI_READ_ONLY` environment variable.",
+			},
+			"defaults": defaultsAttribute(),
+			"name_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Prefix added to the names of the groups, roles, users, templates and vocabularies on opencti, e.g. to isolate test environments sharing a platform. The names in the state and the names referenced by the resources are kept without it. Can also be set with the `OPENCTI_NAME_PREFIX` environment variable.",
+			},
+			"name_suffix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Suffix added to the names of the groups, roles, users, templates and vocabularies on opencti, like `name_prefix`. Can also be set with the `OPENCTI_NAME_SUFFIX` environment variable.",
+			},
+			"ownership_marker": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Marker appended to the description of the groups, roles, case templates, task templates and vocabularies on opencti, and between brackets to the name of the status templates which have no description, e.g. `Managed by Terraform`, to identify the managed objects on the platform. The descriptions and names in the state are kept without it. The objects created before the marker was set are stamped when their resource is next updated. The `opencti_orphans` data source lists the marked objects which are not managed anymore. Can also be set with the `OPENCTI_OWNERSHIP_MARKER` environment variable.",
+			},
 		},
 	}
 }
@@ -79,6 +260,19 @@ func (p *openctiProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	// Defer the configuration of the client when some values are only known after
+	// the apply of other resources, the planning of the opencti resources is then
+	// deferred until those values are known.
+	if !req.Config.Raw.IsFullyKnown() && req.ClientCapabilities.DeferralAllowed {
+		tflog.Info(ctx, "Deferring opencti client configuration as some values are unknown")
+
+		resp.Deferred = &provider.Deferred{
+			Reason: provider.DeferredReasonProviderConfigUnknown,
+		}
+
+		return
+	}
+
 	// If practitioner provided a configuration value for any of the
 	// attributes, it must be a known value.
 
@@ -87,7 +281,8 @@ func (p *openctiProvider) Configure(ctx context.Context, req provider.ConfigureR
 			path.Root("url"),
 			"Unknown opencti URL",
 			"The provider cannot create the opencti API client as there is an unknown configuration value for the opencti URL. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the OPENCTI_URL environment variable.",
+				"Either target apply the source of the value first, set the value statically in the configuration, use the OPENCTI_URL environment variable, "+
+				"or enable deferred actions with a Terraform version supporting them.",
 		)
 	}
 
@@ -100,6 +295,24 @@ func (p *openctiProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.TokenFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_file"),
+			"Unknown opencti token file",
+			"The provider cannot create the opencti API client as there is an unknown configuration value for the opencti token file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the OPENCTI_TOKEN_FILE environment variable.",
+		)
+	}
+
+	if config.TokenCommand.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_command"),
+			"Unknown opencti token command",
+			"The provider cannot create the opencti API client as there is an unknown configuration value for the opencti token command. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -108,14 +321,41 @@ func (p *openctiProvider) Configure(ctx context.Context, req provider.ConfigureR
 	// with Terraform configuration value if set.
 
 	url := os.Getenv("OPENCTI_URL")
-	token := os.Getenv("OPENCTI_TOKEN")
 
 	if !config.URL.IsNull() {
 		url = config.URL.ValueString()
 	}
 
-	if !config.Token.IsNull() {
-		token = config.Token.ValueString()
+	token, diags := resolveToken(ctx, config)
+	resp.Diagnostics.Append(diags...)
+
+	// Credentials used to log in when no token is provided
+	username := os.Getenv("OPENCTI_ADMIN_EMAIL")
+	password := os.Getenv("OPENCTI_ADMIN_PASSWORD")
+
+	if !config.Username.IsNull() {
+		username = config.Username.ValueString()
+	}
+
+	if !config.Password.IsNull() {
+		password = config.Password.ValueString()
+	}
+
+	if !config.Username.IsNull() && (!config.Token.IsNull() || !config.TokenFile.IsNull() || !config.TokenCommand.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Conflicting opencti credentials",
+			"The username and password cannot be set in the configuration together with token, token_file or token_command.",
+		)
+	}
+
+	if token == "" && username != "" && password == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing opencti password",
+			"The provider cannot log in to opencti as there is a missing or empty value for the password. "+
+				"Set the password value in the configuration or use the OPENCTI_ADMIN_PASSWORD environment variable.",
+		)
 	}
 
 	// If any of the expected configurations are missing, return
@@ -131,12 +371,13 @@ func (p *openctiProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
-	if token == "" {
+	if token == "" && username == "" && !resp.Diagnostics.HasError() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("token "),
 			"Missing opencti token",
 			"The provider cannot create the opencti API client as there is a missing or empty value for the opencti token. "+
-				"Set the token value in the configuration or use the OPENCTI_TOKEN environment variable. "+
+				"Set the token, token_file or token_command value in the configuration or use the OPENCTI_TOKEN or OPENCTI_TOKEN_FILE environment variable. "+
+				"Alternatively, set the username and password to log in. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -145,19 +386,196 @@ func (p *openctiProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	// Build the HTTP transport with the TLS and proxy settings
+	transport, diags := newTransport(config)
+	resp.Diagnostics.Append(diags...)
+
+	// Bound each attempt of the requests once it is sent
+	requestTimeout := parseDurationSetting(&resp.Diagnostics, config.RequestTimeout.ValueString(), "request_timeout", "OPENCTI_REQUEST_TIMEOUT", defaultRequestTimeout)
+
+	// Limit the concurrency and the rate of the requests, including the retries
+	limiter, diags := newLimitTransport(&timeoutTransport{base: transport, timeout: requestTimeout}, config)
+	resp.Diagnostics.Append(diags...)
+
+	// Retry transient failures
+	retry, diags := newRetryTransport(limiter, config)
+	resp.Diagnostics.Append(diags...)
+
+	// Health check of the platform
+	waitUntilReady := parseBoolSetting(&resp.Diagnostics, config.WaitForReady, "wait_for_ready", "OPENCTI_WAIT_FOR_READY")
+	skipHealthCheck := parseBoolSetting(&resp.Diagnostics, config.SkipHealthCheck, "skip_health_check", "OPENCTI_SKIP_HEALTH_CHECK")
+	readyTimeout := parseDurationSetting(&resp.Diagnostics, config.ReadyTimeout.ValueString(), "ready_timeout", "OPENCTI_READY_TIMEOUT", defaultReadyTimeout)
+
+	if waitUntilReady && skipHealthCheck {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("skip_health_check"),
+			"Conflicting opencti health check settings",
+			"The health check cannot be skipped when waiting for the platform to be ready.",
+		)
+	}
+
+	// Level of the gocti logs
+	logLevel := os.Getenv("OPENCTI_LOG_LEVEL")
+
+	if !config.LogLevel.IsNull() {
+		logLevel = config.LogLevel.ValueString()
+	}
+
+	goctiLogLevel := parseLogLevel(&resp.Diagnostics, logLevel)
+
+	// Forbid the changes of the resources, e.g. for drift detection
+	readOnly := parseBoolSetting(&resp.Diagnostics, config.ReadOnly, "read_only", "OPENCTI_READ_ONLY")
+
+	// Default values of the resource attributes
+	defaults, diags := parseDefaults(ctx, config.Defaults)
+	resp.Diagnostics.Append(diags...)
+
+	// Prefix and suffix of the names of the managed objects
+	namePrefix := os.Getenv("OPENCTI_NAME_PREFIX")
+
+	if !config.NamePrefix.IsNull() {
+		namePrefix = config.NamePrefix.ValueString()
+	}
+
+	nameSuffix := os.Getenv("OPENCTI_NAME_SUFFIX")
+
+	if !config.NameSuffix.IsNull() {
+		nameSuffix = config.NameSuffix.ValueString()
+	}
+
+	// Marker of the managed objects
+	ownershipMarker := os.Getenv("OPENCTI_OWNERSHIP_MARKER")
+
+	if !config.OwnershipMarker.IsNull() {
+		ownershipMarker = config.OwnershipMarker.ValueString()
+	}
+
+	// User on behalf of whom the requests are made
+	impersonateUser := os.Getenv("OPENCTI_IMPERSONATE_USER")
+
+	if !config.ImpersonateUser.IsNull() {
+		impersonateUser = config.ImpersonateUser.ValueString()
+	}
+
+	// Custom headers added to every request
+	headers := map[string]string{}
+	sensitiveHeaders := map[string]string{}
+
+	if !config.Headers.IsNull() {
+		diags = config.Headers.ElementsAs(ctx, &headers, false)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if !config.SensitiveHeaders.IsNull() {
+		diags = config.SensitiveHeaders.ElementsAs(ctx, &sensitiveHeaders, false)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	ctx = tflog.SetField(ctx, "opencti_url", url)
-	ctx = tflog.SetField(ctx, "opencti_token", token)
-	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "opencti_token")
+	ctx = tflog.SetField(ctx, "opencti_insecure_skip_verify", transport.TLSClientConfig.InsecureSkipVerify)
+
+	allHeaders := map[string]string{}
+
+	for name, value := range headers {
+		allHeaders[name] = value
+		ctx = tflog.SetField(ctx, "opencti_header_"+name, value)
+	}
+
+	for name, value := range sensitiveHeaders {
+		allHeaders[name] = value
+		ctx = tflog.SetField(ctx, "opencti_header_"+name, value)
+		ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "opencti_header_"+name)
+	}
+
+	roundTripper := &headerTransport{base: retry, headers: allHeaders}
+
+	// The applicant header is only set once the impersonated user is resolved, see below
+	applicant := &applicantTransport{base: roundTripper}
+
+	secrets := []string{password}
+	for _, value := range sensitiveHeaders {
+		secrets = append(secrets, value)
+	}
+
+	// gocti bounds the whole request with GOCTI_TIMEOUT, including the waits for the limits and
+	// between the retries, its client is built with it before the options are applied
+	if err := os.Setenv("GOCTI_TIMEOUT", "0s"); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create opencti API Client",
+			"Could not disable the gocti client timeout, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	var logger *slog.Logger
+
+	// Create a new opencti client using the configuration values, logging in first when no
+	// token is set as the login fails as well while opencti is starting
+	newClient := func(ctx context.Context) (*gocti.OpenCTIAPIClient, error) {
+		if token == "" {
+			tflog.Debug(ctx, "Logging in to opencti", map[string]any{"opencti_username": username})
+
+			var err error
+
+			token, err = login(ctx, roundTripper, url, username, password)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		logger = newGoctiLogger(ctx, goctiLogLevel, append(secrets, token)...)
+
+		if skipHealthCheck {
+			return gocti.NewOpenCTIAPIClient(
+				url,
+				token,
+				gocti.WithTransport(applicant),
+				gocti.WithDefaultTimeout(0),
+				gocti.WithLogger(logger),
+			)
+		}
+
+		return gocti.NewOpenCTIAPIClient(
+			url,
+			token,
+			gocti.WithTransport(applicant),
+			gocti.WithDefaultTimeout(0),
+			gocti.WithHealthCheck(),
+			gocti.WithLogger(logger),
+		)
+	}
 
 	tflog.Debug(ctx, "Creating opencti client")
 
-	// Create a new opencti client using the configuration values
-	client, err := gocti.NewOpenCTIAPIClient(
-		url,
-		token,
-		gocti.WithHealthCheck(),
-		gocti.WithLogLevel(slog.LevelInfo),
+	var (
+		client *gocti.OpenCTIAPIClient
+		err    error
 	)
+
+	if waitUntilReady {
+		tflog.Debug(ctx, "Waiting for opencti to be ready", map[string]any{"ready_timeout": readyTimeout.String()})
+
+		client, err = waitForReady(ctx, readyTimeout, newClient)
+	} else {
+		client, err = newClient(ctx)
+	}
+
+	// The token is still missing when the login failed
+	if err != nil && token == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Unable to log in to opencti",
+			"The provider could not retrieve an opencti token with the username and password: "+err.Error(),
+		)
+
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create opencti API Client",
@@ -169,23 +587,106 @@ func (p *openctiProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	ctx = tflog.SetField(ctx, "opencti_token", token)
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "opencti_token")
+
+	gqlClient := &graphqlClient{
+		url:        url,
+		token:      token,
+		httpClient: &http.Client{Transport: applicant},
+		logger:     logger,
+	}
+
+	data := &openctiProviderData{
+		client:          client,
+		graphql:         gqlClient,
+		cache:           newLookupCache(),
+		readOnly:        readOnly,
+		defaults:        defaults,
+		namePrefix:      namePrefix,
+		nameSuffix:      nameSuffix,
+		ownershipMarker: ownershipMarker,
+	}
+
+	// Resolve the impersonated user once the platform is reachable, or on first use when the
+	// health check is skipped as the platform may not be reachable then
+	if impersonateUser != "" && skipHealthCheck {
+		applicant.resolve = func(ctx context.Context) (string, error) {
+			return data.userID(ctx, impersonateUser)
+		}
+
+		ctx = tflog.SetField(ctx, "opencti_impersonate_user", impersonateUser)
+	} else if impersonateUser != "" {
+		applicant.applicant, err = data.userID(ctx, impersonateUser)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("impersonate_user"),
+				"Unable to Resolve Impersonated User",
+				"The provider could not resolve the opencti user to impersonate: "+err.Error(),
+			)
+
+			return
+		}
+
+		ctx = tflog.SetField(ctx, "opencti_impersonate_user", impersonateUser)
+	}
+
+	// Detect the platform version and edition for the resources to check their requirements
+	if !skipHealthCheck {
+		data.platform, err = detectPlatform(ctx, gqlClient)
+
+		switch {
+		case err != nil:
+			resp.Diagnostics.AddWarning(
+				"Unable to Detect opencti Platform",
+				"The provider could not detect the opencti platform version, the resources will not check that "+
+					"the platform supports them.\n\n"+
+					"opencti Client Error: "+err.Error(),
+			)
+		case !data.platform.knownVersion():
+			resp.Diagnostics.AddWarning(
+				"Unknown opencti Version",
+				"The provider could not parse the opencti platform version "+strconv.Quote(data.platform.Version)+
+					", the resources will not check that the platform supports them.",
+			)
+		default:
+			tflog.Info(ctx, "Detected opencti platform", map[string]any{
+				"version": data.platform.Version,
+				"edition": data.platform.edition(),
+			})
+		}
+	}
+
 	// Make the opencti client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = data
+	resp.ResourceData = data
 
 	tflog.Info(ctx, "Configured opencti client", map[string]any{"success": true})
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *openctiProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	// Sorted by name, new data sources are inserted in place
+	return []func() datasource.DataSource{
+		NewCapabilitiesDataSource,
+		NewGraphQLQueryDataSource,
+		NewGroupDataSource,
+		NewGroupsDataSource,
+		NewMarkingDefinitionDataSource,
+		NewMarkingDefinitionsDataSource,
+		NewOrphansDataSource,
+		NewRoleDataSource,
+		NewRolesDataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
 func (p *openctiProvider) Resources(_ context.Context) []func() resource.Resource {
+	// Sorted by name, new resources are inserted in place
 	return []func() resource.Resource{
 		NewCaseTemplateResource,
+		NewGraphQLMutationResource,
 		NewGroupResource,
 		NewMarkingDefinitionResource,
 		NewRoleResource,
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// denyMutation adds an error diagnostic and returns true when the provider is read-only,
+// before a resource sends any mutation to opencti.
+func (d *openctiProviderData) denyMutation(diags *diag.Diagnostics, operation, resourceType string) bool {
+	if d == nil || !d.readOnly {
+		return false
+	}
+
+	diags.AddError(
+		"opencti provider is read-only",
+		fmt.Sprintf("The %s operation of the %s resource is not allowed, the provider is configured with read_only.", operation, resourceType),
+	)
+
+	return true
+}
+
+// checkReadOnlyPlan returns an error diagnostic when the provider is read-only and a change
+// of the resource is planned, making a plan fail on drift.
+func (d *openctiProviderData) checkReadOnlyPlan(state tfsdk.State, plan tfsdk.Plan, resourceType string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if d == nil || !d.readOnly {
+		return diags
+	}
+
+	var change string
+
+	switch {
+	case state.Raw.IsNull():
+		change = "created"
+	case plan.Raw.IsNull():
+		change = "destroyed"
+	case !plan.Raw.Equal(state.Raw):
+		change = "updated"
+	default:
+		return diags
+	}
+
+	diags.AddError(
+		"Change planned on a read-only opencti provider",
+		fmt.Sprintf("The %s resource would be %s, but the provider is configured with read_only.", resourceType, change),
+	)
+
+	return diags
+}
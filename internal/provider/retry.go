@@ -0,0 +1,335 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// retryableGraphQLCodes are the opencti error codes returned for transient failures, e.g. when
+// an entity is locked by a concurrent update or Elasticsearch is overloaded, and whether the
+// mutations failing with them can be replayed, as opencti takes the locks before any change.
+var retryableGraphQLCodes = map[string]bool{
+	"LOCK_ERROR":     true,
+	"DATABASE_ERROR": false,
+}
+
+// retryTransport retries the requests failing with a transient error using an exponential backoff.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// newRetryTransport reads the retry settings from the provider configuration, falling back
+// to the OPENCTI_* environment variables, and wraps the given transport.
+func newRetryTransport(base http.RoundTripper, config openctiProviderModel) (*retryTransport, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	t := &retryTransport{
+		base:       base,
+		maxRetries: defaultMaxRetries,
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+	}
+
+	if v := os.Getenv("OPENCTI_MAX_RETRIES"); v != "" {
+		maxRetries, err := strconv.Atoi(v)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid OPENCTI_MAX_RETRIES value",
+				"The OPENCTI_MAX_RETRIES environment variable must be an integer, got: "+v,
+			)
+		}
+
+		t.maxRetries = maxRetries
+	}
+
+	if !config.MaxRetries.IsNull() {
+		t.maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	if t.maxRetries < 0 {
+		diags.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid opencti max retries",
+			"The number of retries cannot be negative.",
+		)
+	}
+
+	t.minBackoff = parseDurationSetting(&diags, config.MinBackoff.ValueString(), "min_backoff", "OPENCTI_MIN_BACKOFF", t.minBackoff)
+	t.maxBackoff = parseDurationSetting(&diags, config.MaxBackoff.ValueString(), "max_backoff", "OPENCTI_MAX_BACKOFF", t.maxBackoff)
+
+	if t.minBackoff > t.maxBackoff {
+		diags.AddAttributeError(
+			path.Root("min_backoff"),
+			"Invalid opencti backoff",
+			"The minimum backoff cannot be greater than the maximum backoff.",
+		)
+	}
+
+	return t, diags
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// A mutation may have been applied even though it failed, only the queries are replayed once sent
+	query := isGraphQLQuery(req)
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			// The body has been consumed by the previous attempt
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+
+		// Cancelled requests and bodies which cannot be replayed are never retried
+		if ctx.Err() != nil || attempt >= t.maxRetries || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			return resp, err
+		}
+
+		retryable, reason, wait := t.classify(resp, err, query)
+		if !retryable {
+			return resp, err
+		}
+
+		// The delay asked by the server is capped by the maximum backoff
+		wait = min(wait, t.maxBackoff)
+
+		if backoff := t.backoff(attempt); wait < backoff {
+			wait = backoff
+		}
+
+		tflog.Warn(ctx, "Retrying opencti request", map[string]any{
+			"attempt":     attempt + 1,
+			"max_retries": t.maxRetries,
+			"reason":      reason,
+			"wait":        wait.String(),
+		})
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// classify tells whether a response or error is transient, why, and how long the
+// server asked to wait before retrying. Unless the request is a query, it is only
+// retried when it was provably not processed.
+func (t *retryTransport) classify(resp *http.Response, err error, query bool) (bool, string, time.Duration) {
+	if err != nil {
+		transient, notSent := transientError(err)
+
+		return transient && (query || notSent), err.Error(), 0
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return query, resp.Status, retryAfter(resp.Header.Get("Retry-After"))
+	case http.StatusOK:
+	default:
+		return false, "", 0
+	}
+
+	// GraphQL errors are returned with a 200 status, the body has to be inspected
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return false, "", 0
+	}
+
+	var payload struct {
+		Errors []struct {
+			Message    string `json:"message"`
+			Extensions struct {
+				Code string `json:"code"`
+			} `json:"extensions"`
+		} `json:"errors"`
+	}
+
+	if json.Unmarshal(body, &payload) != nil {
+		return false, "", 0
+	}
+
+	for _, gqlErr := range payload.Errors {
+		if replayable, ok := retryableGraphQLCodes[gqlErr.Extensions.Code]; ok && (query || replayable) {
+			return true, gqlErr.Extensions.Code + ": " + gqlErr.Message, retryAfter(resp.Header.Get("Retry-After"))
+		}
+	}
+
+	return false, "", 0
+}
+
+// transientError tells whether a transport error may not happen again, and whether the request
+// was provably not sent, e.g. when the connection could not be established. The certificate and
+// unknown host errors are permanent.
+func transientError(err error) (bool, bool) {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		invalidCert      x509.CertificateInvalidError
+		hostname         x509.HostnameError
+		verification     *tls.CertificateVerificationError
+		recordHeader     tls.RecordHeaderError
+		dnsErr           *net.DNSError
+		opErr            *net.OpError
+		netErr           net.Error
+	)
+
+	switch {
+	case errors.As(err, &unknownAuthority), errors.As(err, &invalidCert), errors.As(err, &hostname),
+		errors.As(err, &verification), errors.As(err, &recordHeader):
+		return false, false
+	case errors.As(err, &dnsErr):
+		// The name is resolved before anything is sent
+		return !dnsErr.IsNotFound, true
+	case errors.As(err, &opErr) && opErr.Op == "dial":
+		return true, true
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return true, false
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return true, false
+	default:
+		return false, false
+	}
+}
+
+// isGraphQLQuery tells whether a request only runs GraphQL queries, which can safely be replayed.
+func isGraphQLQuery(req *http.Request) bool {
+	if req.Method == http.MethodGet {
+		return true
+	}
+
+	if req.GetBody == nil {
+		return false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	defer body.Close()
+
+	var payload struct {
+		Query string `json:"query"`
+	}
+
+	if json.NewDecoder(body).Decode(&payload) != nil {
+		return false
+	}
+
+	operations := graphqlOperationTypes(payload.Query)
+	if len(operations) == 0 {
+		return false
+	}
+
+	for _, operation := range operations {
+		if operation != "query" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// backoff returns the exponential backoff with jitter of the given attempt.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	backoff := t.minBackoff
+	for i := 0; i < attempt && backoff < t.maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	backoff = min(backoff, t.maxBackoff)
+
+	if backoff <= 0 {
+		return 0
+	}
+
+	// Add up to 20% of jitter so concurrent resources do not retry at the same time
+	return backoff + rand.N(backoff/5+1)
+}
+
+// retryAfter parses the Retry-After header, either given in seconds or as an HTTP date.
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// parseDurationSetting parses a duration given in the configuration or in an environment variable.
+func parseDurationSetting(diags *diag.Diagnostics, value, attribute, env string, def time.Duration) time.Duration {
+	if value == "" {
+		value = os.Getenv(env)
+	}
+
+	if value == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid opencti "+attribute,
+			"The value must be a positive duration such as \"30s\" or \"2m\", got: "+value,
+		)
+
+		return def
+	}
+
+	return d
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newGraphQLRequest returns a POST request running the given GraphQL document.
+func newGraphQLRequest(t *testing.T, document string) *http.Request {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": document})
+	if err != nil {
+		t.Fatalf("encoding the request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "http://opencti/graphql", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("creating the request: %v", err)
+	}
+
+	return req
+}
+
+// newResponse returns a response with the given status, Retry-After header and body.
+func newResponse(status int, retryAfter, body string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	if retryAfter != "" {
+		resp.Header.Set("Retry-After", retryAfter)
+	}
+
+	return resp
+}
+
+const (
+	testQuery    = "query { me { id } }"
+	testMutation = "mutation { groupAdd(input: {name: \"SOC\"}) { id } }"
+)
+
+func TestRetryClassify(t *testing.T) {
+	t.Parallel()
+
+	lockError := `{"errors": [{"message": "Lock timeout", "extensions": {"code": "LOCK_ERROR"}}]}`
+	databaseError := `{"errors": [{"message": "Elastic error", "extensions": {"code": "DATABASE_ERROR"}}]}`
+	validationError := `{"errors": [{"message": "Bad input", "extensions": {"code": "VALIDATION_ERROR"}}]}`
+
+	tests := []struct {
+		name     string
+		document string
+		status   int
+		body     string
+		err      error
+		want     bool
+	}{
+		{name: "query success", document: testQuery, status: http.StatusOK, body: `{"data": {}}`, want: false},
+		{name: "query unavailable", document: testQuery, status: http.StatusServiceUnavailable, want: true},
+		{name: "query too many requests", document: testQuery, status: http.StatusTooManyRequests, want: true},
+		{name: "query bad request", document: testQuery, status: http.StatusBadRequest, want: false},
+		{name: "mutation unavailable", document: testMutation, status: http.StatusServiceUnavailable, want: false},
+		{name: "mutation bad gateway", document: testMutation, status: http.StatusBadGateway, want: false},
+		{name: "query lock error", document: testQuery, status: http.StatusOK, body: lockError, want: true},
+		{name: "mutation lock error", document: testMutation, status: http.StatusOK, body: lockError, want: true},
+		{name: "query database error", document: testQuery, status: http.StatusOK, body: databaseError, want: true},
+		{name: "mutation database error", document: testMutation, status: http.StatusOK, body: databaseError, want: false},
+		{name: "query validation error", document: testQuery, status: http.StatusOK, body: validationError, want: false},
+		{name: "query invalid body", document: testQuery, status: http.StatusOK, body: "<html>", want: false},
+		{name: "query connection reset", document: testQuery, err: syscall.ECONNRESET, want: true},
+		{name: "mutation connection reset", document: testMutation, err: syscall.ECONNRESET, want: false},
+		{name: "mutation dial error", document: testMutation, err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, want: true},
+		{name: "mutation temporary DNS error", document: testMutation, err: &net.DNSError{Err: "timeout", IsTemporary: true}, want: true},
+		{name: "query attempt timeout", document: testQuery, err: context.DeadlineExceeded, want: true},
+		{name: "mutation attempt timeout", document: testMutation, err: context.DeadlineExceeded, want: false},
+		{name: "query unknown host", document: testQuery, err: &net.DNSError{Err: "no such host", IsNotFound: true}, want: false},
+		{name: "query unknown authority", document: testQuery, err: x509.UnknownAuthorityError{}, want: false},
+		{name: "query other error", document: testQuery, err: errors.New("unsupported protocol scheme"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var resp *http.Response
+			if tt.err == nil {
+				resp = newResponse(tt.status, "", tt.body)
+			}
+
+			transport := &retryTransport{}
+			query := isGraphQLQuery(newGraphQLRequest(t, tt.document))
+
+			if got, _, _ := transport.classify(resp, tt.err, query); got != tt.want {
+				t.Errorf("classify(%d, %v) of %q = %t, want %t", tt.status, tt.err, tt.document, got, tt.want)
+			}
+
+			// The inspected body is still readable by the caller
+			if resp != nil {
+				if body, _ := io.ReadAll(resp.Body); string(body) != tt.body {
+					t.Errorf("body after classify = %q, want %q", body, tt.body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	lockError := `{"errors": [{"message": "Lock timeout", "extensions": {"code": "LOCK_ERROR"}}]}`
+
+	tests := []struct {
+		name       string
+		document   string
+		status     int
+		retryAfter string
+		body       string
+		wantCalls  int
+	}{
+		{name: "query retried", document: testQuery, status: http.StatusServiceUnavailable, wantCalls: 3},
+		{name: "mutation not replayed", document: testMutation, status: http.StatusServiceUnavailable, wantCalls: 1},
+		{name: "mutation lock error retried", document: testMutation, status: http.StatusOK, body: lockError, wantCalls: 3},
+		{name: "retry after capped", document: testQuery, status: http.StatusTooManyRequests, retryAfter: "3600", wantCalls: 3},
+		{name: "success", document: testQuery, status: http.StatusOK, body: `{"data": {}}`, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+
+			want, _ := json.Marshal(map[string]any{"query": tt.document})
+
+			transport := &retryTransport{
+				base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					calls++
+
+					// Every attempt sends the whole document
+					if body, _ := io.ReadAll(req.Body); !bytes.Equal(body, want) {
+						t.Errorf("attempt %d sent the body %q", calls, body)
+					}
+
+					return newResponse(tt.status, tt.retryAfter, tt.body), nil
+				}),
+				maxRetries: 2,
+				minBackoff: time.Millisecond,
+				maxBackoff: 10 * time.Millisecond,
+			}
+
+			start := time.Now()
+
+			resp, err := transport.RoundTrip(newGraphQLRequest(t, tt.document))
+			if err != nil {
+				t.Fatalf("RoundTrip returned an error: %v", err)
+			}
+			resp.Body.Close()
+
+			if calls != tt.wantCalls {
+				t.Errorf("RoundTrip sent %d attempts, want %d", calls, tt.wantCalls)
+			}
+
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("RoundTrip took %s, the waits are not capped by the maximum backoff", elapsed)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "empty", value: "", want: 0},
+		{name: "seconds", value: "5", want: 5 * time.Second},
+		{name: "negative", value: "-1", want: 0},
+		{name: "past date", value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0},
+		{name: "invalid", value: "soon", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := retryAfter(tt.value); got != tt.want {
+				t.Errorf("retryAfter(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
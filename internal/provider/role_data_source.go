@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &roleDataSource{}
+	_ datasource.DataSourceWithConfigure      = &roleDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &roleDataSource{}
+)
+
+// NewRoleDataSource is a helper function to simplify the provider implementation.
+func NewRoleDataSource() datasource.DataSource {
+	return &roleDataSource{}
+}
+
+// roleDataSource is the data source implementation.
+type roleDataSource struct {
+	data *openctiProviderData
+}
+
+// roleDataSourceModel maps the data source schema data, shaped like roleResourceModel.
+type roleDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Capabilities types.List   `tfsdk:"capabilities"`
+}
+
+// roleAttributes are the GraphQL attributes of the roles read by the data sources.
+const roleAttributes = "id name capabilities { name }"
+
+// roleDataAttributes returns the attributes of a role, computed unless they are lookup keys.
+func roleDataAttributes(lookup bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Optional:            lookup,
+			Computed:            true,
+			MarkdownDescription: "ID of the role.",
+		},
+		"name": schema.StringAttribute{
+			Optional:            lookup,
+			Computed:            true,
+			MarkdownDescription: "Name of the role, without the `name_prefix` and `name_suffix` of the provider.",
+		},
+		"capabilities": schema.ListAttribute{
+			ElementType:         types.StringType,
+			Computed:            true,
+			MarkdownDescription: "Sorted names of the capabilities of the role, as in the `capabilities` of the `opencti_role` resource.",
+		},
+	}
+}
+
+// roleData converts a role read from opencti into the data source model.
+func (d *openctiProviderData) roleData(ctx context.Context, id, name string, capabilities []string) (roleDataSourceModel, diag.Diagnostics) {
+	capabilities = append([]string{}, capabilities...)
+	sort.Strings(capabilities)
+
+	capabilitiesList, diags := types.ListValueFrom(ctx, types.StringType, capabilities)
+
+	return roleDataSourceModel{
+		ID:           types.StringValue(id),
+		Name:         types.StringValue(d.stateName(name)),
+		Capabilities: capabilitiesList,
+	}, diags
+}
+
+// roleDataSourceRequirement is the minimum platform supporting the role lookup.
+var roleDataSourceRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
+}
+
+// Metadata returns the data source type name.
+func (d *roleDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+// Schema defines the schema for the data source.
+func (d *roleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a role by `name` or `id`, e.g. a built-in role or a role managed elsewhere.",
+		Attributes:          roleDataAttributes(true),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *roleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config roleDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ID.IsNull() == config.Name.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Invalid opencti role lookup",
+			"Exactly one of the id and name attributes must be set.",
+		)
+
+		return
+	}
+
+	id := config.ID.ValueString()
+
+	if !config.Name.IsNull() {
+		var (
+			found bool
+			err   error
+		)
+
+		id, found, err = d.data.lookupNamedID(ctx, lookupRole, config.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error looking up opencti role",
+				"Could not look up role "+config.Name.ValueString()+", unexpected error: "+err.Error(),
+			)
+
+			return
+		}
+
+		if !found {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"opencti role not found",
+				"No role is named "+config.Name.ValueString()+".",
+			)
+
+			return
+		}
+	}
+
+	// Read role from opencti
+	role, err := d.data.client.ReadRole(ctx, roleAttributes, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading opencti role", err.Error(),
+		)
+
+		return
+	}
+
+	if role.ID == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"opencti role not found",
+			"No role has the ID "+id+".",
+		)
+
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Role read: %+v", role))
+
+	capabilities := []string{}
+	for _, capability := range role.Capabilities {
+		capabilities = append(capabilities, capability.Name)
+	}
+
+	state, diags := d.data.roleData(ctx, role.ID, role.Name, capabilities)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *roleDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*openctiProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+// ValidateConfig checks that the platform supports the data source.
+func (d *roleDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if d.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.data.platform.check(roleDataSourceRequirement, "opencti_role")...)
+}
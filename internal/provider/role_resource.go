@@ -15,15 +15,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/weisshorn-cyd/gocti"
 	"github.com/weisshorn-cyd/gocti/system"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &roleResource{}
-	_ resource.ResourceWithConfigure   = &roleResource{}
-	_ resource.ResourceWithImportState = &roleResource{}
+	_ resource.Resource                   = &roleResource{}
+	_ resource.ResourceWithConfigure      = &roleResource{}
+	_ resource.ResourceWithImportState    = &roleResource{}
+	_ resource.ResourceWithModifyPlan     = &roleResource{}
+	_ resource.ResourceWithValidateConfig = &roleResource{}
 )
 
 // NewRoleResource is a helper function to simplify the provider implementation.
@@ -33,15 +34,22 @@ func NewRoleResource() resource.Resource {
 
 // roleResource is the resource implementation.
 type roleResource struct {
-	client *gocti.OpenCTIAPIClient
+	data *openctiProviderData
 }
 
 // roleResourceModel maps the resource schema data.
 type roleResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Capabilities types.List   `tfsdk:"capabilities"`
-	LastUpdated  types.String `tfsdk:"last_updated"`
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Capabilities    types.List   `tfsdk:"capabilities"`
+	ImpersonateUser types.String `tfsdk:"impersonate_user"`
+	Timeouts        types.Object `tfsdk:"timeouts"`
+	LastUpdated     types.String `tfsdk:"last_updated"`
+}
+
+// roleRequirement is the minimum platform supporting the roles and their capabilities.
+var roleRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
 }
 
 // Metadata returns the resource type name.
@@ -69,12 +77,19 @@ func (r *roleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				ElementType: types.StringType,
 				Required:    true,
 			},
+			"impersonate_user": impersonateUserAttribute(),
+			"timeouts":         timeoutsAttribute(),
 		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "create", "opencti_role") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan roleResourceModel
 
@@ -85,11 +100,22 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "create", "opencti_role")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, "Creating roles")
 
 	// Create new role
-	createdRole, err := r.client.CreateRole(ctx, "id name", system.RoleAddInput{
-		Name: plan.Name.ValueString(),
+	createdRole, err := r.data.client.CreateRole(ctx, "id name", system.RoleAddInput{
+		Name:        r.data.managedName(plan.Name.ValueString()),
+		Description: r.data.markDescription(""),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -102,16 +128,16 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	tflog.Debug(ctx, fmt.Sprintf("Role created: %+v", createdRole))
 
+	r.data.cache.set(lookupRole, createdRole.Name, createdRole.ID)
+
 	capabilitesAssigned := []string{}
 
 	// Get capabilities to assign
-	capabilities := []system.Capabilities{}
 	for _, capability := range plan.Capabilities.Elements() {
-		capabilities = append(capabilities, system.Capabilities(strings.Trim(capability.String(), "\"")))
 		capabilitesAssigned = append(capabilitesAssigned, strings.Trim(capability.String(), "\""))
 	}
 
-	capabilitiesIDs, err := system.Capability{}.IDsByNames(ctx, r.client, capabilities)
+	capabilitiesIDs, err := r.data.capabilityIDs(ctx, capabilitesAssigned)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving capabilities IDs",
@@ -122,7 +148,7 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	for _, capability := range capabilitiesIDs {
-		if _, err := createdRole.AssignCapability(ctx, r.client, capability); err != nil {
+		if _, err := createdRole.AssignCapability(ctx, r.data.client, capability); err != nil {
 			resp.Diagnostics.AddError(
 				"Error assigning capabilities",
 				"Could not create role, unexpected error: "+err.Error(),
@@ -138,9 +164,11 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 	resp.Diagnostics.Append(diags...)
 
 	plan = roleResourceModel{
-		ID:           types.StringValue(createdRole.ID),
-		Name:         types.StringValue(createdRole.Name),
-		Capabilities: capabilitiesAssignedList,
+		ID:              types.StringValue(createdRole.ID),
+		Name:            types.StringValue(r.data.stateName(createdRole.Name)),
+		Capabilities:    capabilitiesAssignedList,
+		ImpersonateUser: plan.ImpersonateUser,
+		Timeouts:        plan.Timeouts,
 	}
 
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
@@ -166,8 +194,12 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "read", "opencti_role")
+
 	// Read role from opencti
-	role, err := r.client.ReadRole(ctx, "id name capabilities {id name}", state.ID.ValueString())
+	role, err := r.data.client.ReadRole(ctx, "id name capabilities {id name}", state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading opencti role", err.Error(),
@@ -179,7 +211,7 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	tflog.Debug(ctx, fmt.Sprintf("Role read: %+v", role))
 
 	state.ID = types.StringValue(role.ID)
-	state.Name = types.StringValue(role.Name)
+	state.Name = types.StringValue(r.data.stateName(role.Name))
 
 	capabilities := []string{}
 	for _, capability := range role.Capabilities {
@@ -204,6 +236,11 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "update", "opencti_role") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan roleResourceModel
 
@@ -214,7 +251,17 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	role, err := r.client.ReadRole(ctx, "id name capabilities {id name}", plan.ID.ValueString())
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "update", "opencti_role")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.data.client.ReadRole(ctx, "id name description capabilities {id name}", plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading opencti role", err.Error(),
@@ -237,12 +284,7 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	capabilitiesOfRole := []string{}
 
 	// Get all capabilities IDs to assign
-	capabilities := []system.Capabilities{}
-	for _, capability := range capabilitiesPlan {
-		capabilities = append(capabilities, system.Capabilities(capability))
-	}
-
-	capabilitiesIDs, err := system.Capability{}.IDsByNames(ctx, r.client, capabilities)
+	capabilitiesIDs, err := r.data.capabilityIDs(ctx, capabilitiesPlan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving capabilities IDs", err.Error(),
@@ -256,7 +298,7 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		if !slices.Contains(capabilitiesPlan, capability.Name) {
 			tflog.Info(ctx, fmt.Sprintf("Removing capability: %s", capability.Name))
 
-			if _, err := role.UnassignCapability(ctx, r.client, capability.ID); err != nil {
+			if _, err := role.UnassignCapability(ctx, r.data.client, capability.ID); err != nil {
 				resp.Diagnostics.AddError(
 					"Error Unassigning OpenCTI Capability from Role", err.Error(),
 				)
@@ -273,7 +315,7 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		if !slices.Contains(capabilitiesOfRole, capability) {
 			tflog.Info(ctx, fmt.Sprintf("Adding capability: %s", capability))
 
-			if _, err := role.AssignCapability(ctx, r.client, capabilitiesIDs[i]); err != nil {
+			if _, err := role.AssignCapability(ctx, r.data.client, capabilitiesIDs[i]); err != nil {
 				resp.Diagnostics.AddError(
 					"Error assigning capability to role", err.Error(),
 				)
@@ -294,6 +336,17 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	plan.Capabilities = capabilitiesList
 
+	// Stamp a role created before the ownership marker was set, keeping its description
+	if !r.data.isMarked(role.Description) {
+		if err := r.data.restamp(ctx, "opencti_role", plan.ID.ValueString(), "description", role.Description, r.data.markDescription(role.Description)); err != nil {
+			resp.Diagnostics.AddError(
+				"Error stamping role", err.Error(),
+			)
+
+			return
+		}
+	}
+
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	diags = resp.State.Set(ctx, plan)
@@ -306,6 +359,11 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "delete", "opencti_role") {
+		return
+	}
+
 	// Retrieve values from state
 	var state roleResourceModel
 
@@ -316,7 +374,17 @@ func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	if _, err := r.client.DeleteRole(ctx, state.ID.ValueString()); err != nil {
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "delete", "opencti_role")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, state.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.data.client.DeleteRole(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting OpenCTI Role",
 			"Could not delete role, unexpected error: "+err.Error(),
@@ -324,6 +392,8 @@ func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 		return
 	}
+
+	r.data.cache.remove(lookupRole, r.data.managedName(state.Name.ValueString()))
 }
 
 // Configure adds the provider configured client to the resource.
@@ -334,17 +404,37 @@ func (r *roleResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*gocti.OpenCTIAPIClient)
+	data, ok := req.ProviderData.(*openctiProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *gocti.OpenCTIAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.data = data
+}
+
+// ValidateConfig checks that the platform supports the resource.
+func (r *roleResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.platform.check(roleRequirement, "opencti_role")...)
+}
+
+// ModifyPlan checks that the provider allows the planned change.
+func (r *roleResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the provider is not configured, e.g. when its configuration is deferred
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.checkReadOnlyPlan(req.State, resp.Plan, "opencti_role")...)
 }
 
 func (r *roleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &rolesDataSource{}
+	_ datasource.DataSourceWithConfigure      = &rolesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &rolesDataSource{}
+)
+
+// NewRolesDataSource is a helper function to simplify the provider implementation.
+func NewRolesDataSource() datasource.DataSource {
+	return &rolesDataSource{}
+}
+
+// rolesDataSource is the data source implementation.
+type rolesDataSource struct {
+	data *openctiProviderData
+}
+
+// rolesDataSourceModel maps the data source schema data.
+type rolesDataSourceModel struct {
+	NameRegex types.String          `tfsdk:"name_regex"`
+	Roles     []roleDataSourceModel `tfsdk:"roles"`
+}
+
+// rolesRequirement is the minimum platform supporting the role listing.
+var rolesRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
+}
+
+// Metadata returns the data source type name.
+func (d *rolesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_roles"
+}
+
+// Schema defines the schema for the data source.
+func (d *rolesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the roles of the platform.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Regular expression matched by the names of the roles, without the `name_prefix` and `name_suffix` of the provider.",
+			},
+			"roles": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Roles matching `name_regex`, sorted by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: roleDataAttributes(false),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *rolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state rolesDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+
+	if !state.NameRegex.IsNull() {
+		var err error
+
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid name regular expression",
+				"Could not parse the regular expression, unexpected error: "+err.Error(),
+			)
+
+			return
+		}
+	}
+
+	roles, err := d.data.client.ListRoles(ctx, roleAttributes, true, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing opencti roles",
+			"Could not list roles, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	state.Roles = []roleDataSourceModel{}
+
+	for _, role := range roles {
+		capabilities := []string{}
+		for _, capability := range role.Capabilities {
+			capabilities = append(capabilities, capability.Name)
+		}
+
+		model, diags := d.data.roleData(ctx, role.ID, role.Name, capabilities)
+		resp.Diagnostics.Append(diags...)
+
+		if nameRegex != nil && !nameRegex.MatchString(model.Name.ValueString()) {
+			continue
+		}
+
+		state.Roles = append(state.Roles, model)
+	}
+
+	sort.Slice(state.Roles, func(i, j int) bool {
+		return state.Roles[i].Name.ValueString() < state.Roles[j].Name.ValueString()
+	})
+
+	tflog.Debug(ctx, fmt.Sprintf("Roles found: %d", len(state.Roles)))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *rolesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*openctiProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.data = data
+}
+
+// ValidateConfig checks that the platform supports the data source.
+func (d *rolesDataSource) ValidateConfig(_ context.Context, _ datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if d.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.data.platform.check(rolesRequirement, "opencti_roles")...)
+}
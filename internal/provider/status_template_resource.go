@@ -14,15 +14,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/weisshorn-cyd/gocti"
 	"github.com/weisshorn-cyd/gocti/system"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &statusTemplateResource{}
-	_ resource.ResourceWithConfigure   = &statusTemplateResource{}
-	_ resource.ResourceWithImportState = &statusTemplateResource{}
+	_ resource.Resource                   = &statusTemplateResource{}
+	_ resource.ResourceWithConfigure      = &statusTemplateResource{}
+	_ resource.ResourceWithImportState    = &statusTemplateResource{}
+	_ resource.ResourceWithModifyPlan     = &statusTemplateResource{}
+	_ resource.ResourceWithValidateConfig = &statusTemplateResource{}
 )
 
 // NewStatusTemplateResource is a helper function to simplify the provider implementation.
@@ -32,16 +33,18 @@ func NewStatusTemplateResource() resource.Resource {
 
 // statusTemplateResource is the resource implementation.
 type statusTemplateResource struct {
-	client *gocti.OpenCTIAPIClient
+	data *openctiProviderData
 }
 
 // statusTemplateResourceModel maps the resource schema data.
 type statusTemplateResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Color       types.String `tfsdk:"color"`
-	Workflows   types.List   `tfsdk:"workflows"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Color           types.String `tfsdk:"color"`
+	Workflows       types.List   `tfsdk:"workflows"`
+	ImpersonateUser types.String `tfsdk:"impersonate_user"`
+	Timeouts        types.Object `tfsdk:"timeouts"`
+	LastUpdated     types.String `tfsdk:"last_updated"`
 }
 
 type workflowModel struct {
@@ -49,6 +52,11 @@ type workflowModel struct {
 	Order  types.Int64  `tfsdk:"order"`
 }
 
+// statusTemplateRequirement is the minimum platform supporting the status templates.
+var statusTemplateRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
+}
+
 // Metadata returns the resource type name.
 func (r *statusTemplateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_status_template"
@@ -89,12 +97,19 @@ func (r *statusTemplateResource) Schema(_ context.Context, _ resource.SchemaRequ
 					listplanmodifier.RequiresReplace(),
 				},
 			},
+			"impersonate_user": impersonateUserAttribute(),
+			"timeouts":         timeoutsAttribute(),
 		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *statusTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "create", "opencti_status_template") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan statusTemplateResourceModel
 
@@ -105,6 +120,16 @@ func (r *statusTemplateResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "create", "opencti_status_template")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Extract the workflows (if provided)
 	var workflows []workflowModel
 	// Check if `workflows` is not null and is known
@@ -119,8 +144,8 @@ func (r *statusTemplateResource) Create(ctx context.Context, req resource.Create
 	tflog.Info(ctx, "Creating status templates")
 
 	// Create new status template
-	createdStatus, err := r.client.CreateStatusTemplate(ctx, "id name color", system.StatusTemplateAddInput{
-		Name:  plan.Name.ValueString(),
+	createdStatus, err := r.data.client.CreateStatusTemplate(ctx, "id name color", system.StatusTemplateAddInput{
+		Name:  r.data.markName(r.data.managedName(plan.Name.ValueString())),
 		Color: plan.Color.ValueString(),
 	})
 	if err != nil {
@@ -147,7 +172,7 @@ func (r *statusTemplateResource) Create(ctx context.Context, req resource.Create
 	for _, workflow := range workflows {
 		tflog.Info(ctx, fmt.Sprintf("Assigning status %s to entity %s with order %d", plan.Name.ValueString(), workflow.Entity.ValueString(), workflow.Order.ValueInt64()))
 
-		_, err = subType.SetStatusInWorkFlow(ctx, r.client, workflow.Entity.ValueString(), plan.ID.ValueString(), int(workflow.Order.ValueInt64()), "GLOBAL")
+		_, err = subType.SetStatusInWorkFlow(ctx, r.data.client, workflow.Entity.ValueString(), plan.ID.ValueString(), int(workflow.Order.ValueInt64()), "GLOBAL")
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error setting status in workflow",
@@ -216,8 +241,12 @@ func (r *statusTemplateResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "read", "opencti_status_template")
+
 	// Read status template from opencti
-	statusTemplate, err := r.client.ReadStatusTemplate(ctx, "id name color usages", state.ID.ValueString())
+	statusTemplate, err := r.data.client.ReadStatusTemplate(ctx, "id name color usages", state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading opencti status template", err.Error(),
@@ -229,7 +258,7 @@ func (r *statusTemplateResource) Read(ctx context.Context, req resource.ReadRequ
 	tflog.Debug(ctx, fmt.Sprintf("Status template read: %+v", statusTemplate))
 
 	state.ID = types.StringValue(statusTemplate.ID)
-	state.Name = types.StringValue(statusTemplate.Name)
+	state.Name = types.StringValue(r.data.stateName(r.data.unmarkName(statusTemplate.Name)))
 	state.Color = types.StringValue(statusTemplate.Color)
 
 	// It is not simple to retrieve the workflow of all the entities, compare only the number of usages.
@@ -261,10 +290,79 @@ func (r *statusTemplateResource) Read(ctx context.Context, req resource.ReadRequ
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *statusTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "update", "opencti_status_template") {
+		return
+	}
+
+	// Only the timeouts can be updated in place, keep the current state with the new timeouts
+	var plan, state statusTemplateResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Name.Equal(state.Name) || !plan.Color.Equal(state.Color) {
+		resp.Diagnostics.AddError(
+			"Error updating status template",
+			"The name and color of a status template cannot be updated in place.",
+		)
+
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "update", "opencti_status_template")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	statusTemplate, err := r.data.client.ReadStatusTemplate(ctx, "id name", state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading opencti status template", err.Error(),
+		)
+
+		return
+	}
+
+	// Stamp a status template created before the ownership marker was set
+	if err := r.data.restamp(ctx, "opencti_status_template", state.ID.ValueString(), "name", statusTemplate.Name, r.data.markName(r.data.managedName(state.Name.ValueString()))); err != nil {
+		resp.Diagnostics.AddError(
+			"Error stamping status template", err.Error(),
+		)
+
+		return
+	}
+
+	state.Timeouts = plan.Timeouts
+	state.ImpersonateUser = plan.ImpersonateUser
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *statusTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "delete", "opencti_status_template") {
+		return
+	}
+
 	// Retrieve values from state
 	var state statusTemplateResourceModel
 
@@ -275,7 +373,17 @@ func (r *statusTemplateResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	if _, err := r.client.DeleteStatusTemplate(ctx, state.ID.ValueString()); err != nil {
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "delete", "opencti_status_template")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, state.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.data.client.DeleteStatusTemplate(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting OpenCTI Status Template",
 			"Could not delete status template, unexpected error: "+err.Error(),
@@ -293,17 +401,37 @@ func (r *statusTemplateResource) Configure(_ context.Context, req resource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*gocti.OpenCTIAPIClient)
+	data, ok := req.ProviderData.(*openctiProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *gocti.OpenCTIAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.data = data
+}
+
+// ValidateConfig checks that the platform supports the resource.
+func (r *statusTemplateResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.platform.check(statusTemplateRequirement, "opencti_status_template")...)
+}
+
+// ModifyPlan checks that the provider allows the planned change.
+func (r *statusTemplateResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the provider is not configured, e.g. when its configuration is deferred
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.checkReadOnlyPlan(req.State, resp.Plan, "opencti_status_template")...)
 }
 
 func (r *statusTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -12,15 +12,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/weisshorn-cyd/gocti"
 	"github.com/weisshorn-cyd/gocti/system"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &taskTemplateResource{}
-	_ resource.ResourceWithConfigure   = &taskTemplateResource{}
-	_ resource.ResourceWithImportState = &taskTemplateResource{}
+	_ resource.Resource                   = &taskTemplateResource{}
+	_ resource.ResourceWithConfigure      = &taskTemplateResource{}
+	_ resource.ResourceWithImportState    = &taskTemplateResource{}
+	_ resource.ResourceWithModifyPlan     = &taskTemplateResource{}
+	_ resource.ResourceWithValidateConfig = &taskTemplateResource{}
 )
 
 // NewTaskTemplateResource is a helper function to simplify the provider implementation.
@@ -30,15 +31,22 @@ func NewTaskTemplateResource() resource.Resource {
 
 // taskTemplateResource is the resource implementation.
 type taskTemplateResource struct {
-	client *gocti.OpenCTIAPIClient
+	data *openctiProviderData
 }
 
 // taskTemplateResourceModel maps the resource schema data.
 type taskTemplateResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	ImpersonateUser types.String `tfsdk:"impersonate_user"`
+	Timeouts        types.Object `tfsdk:"timeouts"`
+	LastUpdated     types.String `tfsdk:"last_updated"`
+}
+
+// taskTemplateRequirement is the minimum platform supporting the task templates.
+var taskTemplateRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
 }
 
 // Metadata returns the resource type name.
@@ -65,12 +73,19 @@ func (r *taskTemplateResource) Schema(_ context.Context, _ resource.SchemaReques
 			"description": schema.StringAttribute{
 				Required: true,
 			},
+			"impersonate_user": impersonateUserAttribute(),
+			"timeouts":         timeoutsAttribute(),
 		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *taskTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "create", "opencti_task_template") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan taskTemplateResourceModel
 
@@ -81,12 +96,22 @@ func (r *taskTemplateResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "create", "opencti_task_template")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, "Creating task templates")
 
 	// Create new task template
-	createdTask, err := r.client.CreateTaskTemplate(ctx, "id name description", system.TaskTemplateAddInput{
-		Name:        plan.Name.ValueString(),
-		Description: plan.Description.ValueString(),
+	createdTask, err := r.data.client.CreateTaskTemplate(ctx, "id name description", system.TaskTemplateAddInput{
+		Name:        r.data.managedName(plan.Name.ValueString()),
+		Description: r.data.markDescription(plan.Description.ValueString()),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -123,8 +148,12 @@ func (r *taskTemplateResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "read", "opencti_task_template")
+
 	// Get task template from opencti
-	task, err := r.client.ReadTaskTemplate(ctx, "id name description", state.ID.ValueString())
+	task, err := r.data.client.ReadTaskTemplate(ctx, "id name description", state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading opencti task template", err.Error(),
@@ -136,8 +165,8 @@ func (r *taskTemplateResource) Read(ctx context.Context, req resource.ReadReques
 	tflog.Debug(ctx, fmt.Sprintf("Task template read: %+v", task))
 
 	state.ID = types.StringValue(task.ID)
-	state.Name = types.StringValue(task.Name)
-	state.Description = types.StringValue(task.Description)
+	state.Name = types.StringValue(r.data.stateName(task.Name))
+	state.Description = types.StringValue(r.data.unmarkDescription(task.Description))
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -150,6 +179,11 @@ func (r *taskTemplateResource) Read(ctx context.Context, req resource.ReadReques
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *taskTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "update", "opencti_task_template") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan taskTemplateResourceModel
 
@@ -160,12 +194,22 @@ func (r *taskTemplateResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "update", "opencti_task_template")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, "Updating task templates")
 
 	// Create new task template
-	createdTask, err := r.client.CreateTaskTemplate(ctx, "id name description", system.TaskTemplateAddInput{
-		Name:        plan.Name.ValueString(),
-		Description: plan.Description.ValueString(),
+	createdTask, err := r.data.client.CreateTaskTemplate(ctx, "id name description", system.TaskTemplateAddInput{
+		Name:        r.data.managedName(plan.Name.ValueString()),
+		Description: r.data.markDescription(plan.Description.ValueString()),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -192,6 +236,11 @@ func (r *taskTemplateResource) Update(ctx context.Context, req resource.UpdateRe
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *taskTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "delete", "opencti_task_template") {
+		return
+	}
+
 	// Retrieve values from state
 	var state taskTemplateResourceModel
 
@@ -202,7 +251,17 @@ func (r *taskTemplateResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	if _, err := r.client.DeleteTaskTemplate(ctx, state.ID.ValueString()); err != nil {
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "delete", "opencti_task_template")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, state.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.data.client.DeleteTaskTemplate(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting OpenCTI Task Template",
 			"Could not delete task template, unexpected error: "+err.Error(),
@@ -220,17 +279,37 @@ func (r *taskTemplateResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*gocti.OpenCTIAPIClient)
+	data, ok := req.ProviderData.(*openctiProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *gocti.OpenCTIAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.data = data
+}
+
+// ValidateConfig checks that the platform supports the resource.
+func (r *taskTemplateResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.platform.check(taskTemplateRequirement, "opencti_task_template")...)
+}
+
+// ModifyPlan checks that the provider allows the planned change.
+func (r *taskTemplateResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the provider is not configured, e.g. when its configuration is deferred
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.checkReadOnlyPlan(req.State, resp.Plan, "opencti_task_template")...)
 }
 
 func (r *taskTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultTimeout is used for the operations without a configured timeout.
+const defaultTimeout = 20 * time.Minute
+
+// timeoutsAttribute returns the schema of the timeouts nested attribute shared by all resources.
+func timeoutsAttribute() schema.SingleNestedAttribute {
+	attributes := map[string]schema.Attribute{}
+
+	for _, operation := range []string{"create", "read", "update", "delete"} {
+		attributes[operation] = schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Timeout of the %s operation, as a duration such as \"30s\" or \"10m\" (defaults to 20m).", operation),
+			Validators: []validator.String{
+				durationValidator{},
+			},
+		}
+	}
+
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "Timeouts of the operations on the resource, bounding all their requests including the retries and the waits for the request limits.",
+		Attributes:          attributes,
+	}
+}
+
+// withTimeout returns a context cancelled when the timeout of the operation is reached.
+func withTimeout(ctx context.Context, timeouts types.Object, operation string) (context.Context, context.CancelFunc) {
+	timeout := defaultTimeout
+
+	if !timeouts.IsNull() && !timeouts.IsUnknown() {
+		if v, ok := timeouts.Attributes()[operation].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+			// The value has already been checked by durationValidator
+			if d, err := time.ParseDuration(v.ValueString()); err == nil {
+				timeout = d
+			}
+		}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// checkTimeout reports an explicit error naming the operation and the resource when
+// the operation failed because its timeout was reached.
+func checkTimeout(ctx context.Context, diags *diag.Diagnostics, operation, resourceType string) {
+	if !diags.HasError() || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return
+	}
+
+	diags.AddError(
+		fmt.Sprintf("Timeout during %s of %s", operation, resourceType),
+		fmt.Sprintf("The %s operation on the %s resource did not complete before its timeout. "+
+			"The timeout can be increased with the %s attribute of the timeouts block.", operation, resourceType, operation),
+	)
+}
+
+// durationValidator checks that a string attribute is a valid duration.
+type durationValidator struct{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration such as \"30s\" or \"10m\""
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if d, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid duration",
+			fmt.Sprintf("The %s, got: %s", v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
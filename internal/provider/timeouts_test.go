@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}
+
+	timeouts := func(create string) types.Object {
+		return types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"create": types.StringValue(create),
+			"read":   types.StringNull(),
+			"update": types.StringNull(),
+			"delete": types.StringNull(),
+		})
+	}
+
+	tests := []struct {
+		name      string
+		timeouts  types.Object
+		operation string
+		want      time.Duration
+	}{
+		{name: "no timeouts", timeouts: types.ObjectNull(attrTypes), operation: "create", want: defaultTimeout},
+		{name: "unknown timeouts", timeouts: types.ObjectUnknown(attrTypes), operation: "create", want: defaultTimeout},
+		{name: "configured", timeouts: timeouts("45m"), operation: "create", want: 45 * time.Minute},
+		{name: "above the request timeout", timeouts: timeouts("30s"), operation: "create", want: 30 * time.Second},
+		{name: "other operation", timeouts: timeouts("45m"), operation: "delete", want: defaultTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			start := time.Now()
+
+			ctx, cancel := withTimeout(t.Context(), tt.timeouts, tt.operation)
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatalf("withTimeout(%s) returned a context without deadline", tt.operation)
+			}
+
+			if got := deadline.Sub(start); got < tt.want || got > tt.want+time.Second {
+				t.Errorf("withTimeout(%s) = %s, want %s", tt.operation, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// resolveToken returns the opencti token from the first configured source.
+//
+// A token set in the configuration, either directly or through token_file or
+// token_command, takes precedence over the OPENCTI_TOKEN and OPENCTI_TOKEN_FILE
+// environment variables. Setting several sources at the same level is an error.
+// The environment variables are ignored when a username is configured, so that
+// the configured credentials are used to log in.
+func resolveToken(ctx context.Context, config openctiProviderModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	configured := []string{}
+
+	if !config.Token.IsNull() {
+		configured = append(configured, "token")
+	}
+
+	if !config.TokenFile.IsNull() {
+		configured = append(configured, "token_file")
+	}
+
+	if !config.TokenCommand.IsNull() {
+		configured = append(configured, "token_command")
+	}
+
+	if len(configured) > 1 {
+		diags.AddAttributeError(
+			path.Root(configured[1]),
+			"Conflicting opencti token sources",
+			"Only one of token, token_file and token_command can be set, got: "+strings.Join(configured, ", ")+".",
+		)
+
+		return "", diags
+	}
+
+	switch {
+	case !config.Token.IsNull():
+		return config.Token.ValueString(), diags
+	case !config.TokenFile.IsNull():
+		return readTokenFile(config.TokenFile.ValueString(), path.Root("token_file"))
+	case !config.TokenCommand.IsNull():
+		var command []string
+
+		diags.Append(config.TokenCommand.ElementsAs(ctx, &command, false)...)
+
+		if diags.HasError() {
+			return "", diags
+		}
+
+		return runTokenCommand(ctx, command)
+	}
+
+	// Fall back to the environment variables
+	token := os.Getenv("OPENCTI_TOKEN")
+	tokenFile := os.Getenv("OPENCTI_TOKEN_FILE")
+
+	if !config.Username.IsNull() {
+		if token != "" || tokenFile != "" {
+			diags.AddAttributeWarning(
+				path.Root("username"),
+				"Ignored opencti token environment variables",
+				"The OPENCTI_TOKEN and OPENCTI_TOKEN_FILE environment variables are ignored as the username is set in the configuration, "+
+					"the provider logs in with the username and password instead.",
+			)
+		}
+
+		return "", diags
+	}
+
+	if token != "" && tokenFile != "" {
+		diags.AddAttributeError(
+			path.Root("token"),
+			"Conflicting opencti token sources",
+			"Only one of the OPENCTI_TOKEN and OPENCTI_TOKEN_FILE environment variables can be set.",
+		)
+
+		return "", diags
+	}
+
+	if tokenFile != "" {
+		return readTokenFile(tokenFile, path.Root("token_file"))
+	}
+
+	return token, diags
+}
+
+// readTokenFile returns the token stored in a file, without the surrounding whitespaces.
+func readTokenFile(filename string, attribute path.Path) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		diags.AddAttributeError(
+			attribute,
+			"Unable to read opencti token file",
+			"The provider could not read the token file "+filename+": "+err.Error(),
+		)
+
+		return "", diags
+	}
+
+	return strings.TrimSpace(string(data)), diags
+}
+
+// runTokenCommand runs a local helper and returns its standard output as the token.
+func runTokenCommand(ctx context.Context, command []string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if len(command) == 0 || command[0] == "" {
+		diags.AddAttributeError(
+			path.Root("token_command"),
+			"Invalid opencti token command",
+			"The token command must contain at least the program to run.",
+		)
+
+		return "", diags
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		diags.AddAttributeError(
+			path.Root("token_command"),
+			"Unable to run opencti token command",
+			"The token command "+command[0]+" failed: "+err.Error()+"\n\n"+strings.TrimSpace(stderr.String()),
+		)
+
+		return "", diags
+	}
+
+	return strings.TrimSpace(stdout.String()), diags
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The environment variables prevent running these tests in parallel.
+func TestResolveToken(t *testing.T) {
+	dir := t.TempDir()
+
+	configFile := filepath.Join(dir, "config-token")
+	if err := os.WriteFile(configFile, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatalf("writing the token file: %v", err)
+	}
+
+	envFile := filepath.Join(dir, "env-token")
+	if err := os.WriteFile(envFile, []byte(" env-file-token "), 0o600); err != nil {
+		t.Fatalf("writing the token file: %v", err)
+	}
+
+	command := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("echo"),
+		types.StringValue("command-token"),
+	})
+
+	tests := []struct {
+		name        string
+		config      openctiProviderModel
+		envToken    string
+		envFile     string
+		want        string
+		wantError   bool
+		wantWarning bool
+	}{
+		{name: "nothing", want: ""},
+		{name: "config token", config: openctiProviderModel{Token: types.StringValue("config-token")}, want: "config-token"},
+		{name: "config token file", config: openctiProviderModel{TokenFile: types.StringValue(configFile)}, want: "file-token"},
+		{name: "config token command", config: openctiProviderModel{TokenCommand: command}, want: "command-token"},
+		{name: "env token", envToken: "env-token", want: "env-token"},
+		{name: "env token file", envFile: envFile, want: "env-file-token"},
+		{name: "config token over env token", config: openctiProviderModel{Token: types.StringValue("config-token")}, envToken: "env-token", want: "config-token"},
+		{name: "config token file over env token file", config: openctiProviderModel{TokenFile: types.StringValue(configFile)}, envFile: envFile, want: "file-token"},
+		{name: "config username over env token", config: openctiProviderModel{Username: types.StringValue("admin@opencti.io")}, envToken: "env-token", want: "", wantWarning: true},
+		{name: "config username over env token file", config: openctiProviderModel{Username: types.StringValue("admin@opencti.io")}, envFile: envFile, want: "", wantWarning: true},
+		{name: "config username without env token", config: openctiProviderModel{Username: types.StringValue("admin@opencti.io")}, want: ""},
+		{name: "conflicting config sources", config: openctiProviderModel{Token: types.StringValue("config-token"), TokenFile: types.StringValue(configFile)}, wantError: true},
+		{name: "conflicting env sources", envToken: "env-token", envFile: envFile, wantError: true},
+		{name: "missing token file", config: openctiProviderModel{TokenFile: types.StringValue(filepath.Join(dir, "missing"))}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENCTI_TOKEN", tt.envToken)
+			t.Setenv("OPENCTI_TOKEN_FILE", tt.envFile)
+
+			got, diags := resolveToken(t.Context(), tt.config)
+
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("resolveToken() errors = %v, want error %t", diags.Errors(), tt.wantError)
+			}
+
+			if hasWarning := diags.WarningsCount() > 0; hasWarning != tt.wantWarning {
+				t.Errorf("resolveToken() warnings = %v, want warning %t", diags.Warnings(), tt.wantWarning)
+			}
+
+			if !tt.wantError && got != tt.want {
+				t.Errorf("resolveToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
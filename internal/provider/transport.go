@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// defaultRequestTimeout is the maximum duration of an attempt of a request, the gocti default.
+const defaultRequestTimeout = 10 * time.Second
+
+// newTransport builds the HTTP transport used by the opencti client from the
+// provider TLS and proxy configuration, falling back to the OPENCTI_* environment variables.
+func newTransport(config openctiProviderModel) (*http.Transport, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	insecure := os.Getenv("OPENCTI_INSECURE_SKIP_VERIFY")
+	if insecure != "" {
+		v, err := strconv.ParseBool(insecure)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("insecure_skip_verify"),
+				"Invalid OPENCTI_INSECURE_SKIP_VERIFY value",
+				"The OPENCTI_INSECURE_SKIP_VERIFY environment variable must be a boolean, got: "+insecure,
+			)
+		}
+
+		tlsConfig.InsecureSkipVerify = v
+	}
+
+	if !config.InsecureSkipVerify.IsNull() {
+		tlsConfig.InsecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+	}
+
+	// Custom CA bundle, added on top of the system pool
+	caCert, caPath, d := loadPEM(config.CACert, "ca_cert", "OPENCTI_CA_CERT", config.CACertFile, "ca_cert_file", "OPENCTI_CA_CERT_FILE")
+	diags.Append(d...)
+
+	if len(caCert) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(caCert) {
+			diags.AddAttributeError(
+				caPath,
+				"Invalid opencti CA certificate",
+				"The provider could not find any valid PEM encoded certificate in the opencti CA bundle.",
+			)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	// Client certificate for mutual TLS
+	clientCert, certPath, d := loadPEM(config.ClientCert, "client_cert", "OPENCTI_CLIENT_CERT", config.ClientCertFile, "client_cert_file", "OPENCTI_CLIENT_CERT_FILE")
+	diags.Append(d...)
+
+	clientKey, keyPath, d := loadPEM(config.ClientKey, "client_key", "OPENCTI_CLIENT_KEY", config.ClientKeyFile, "client_key_file", "OPENCTI_CLIENT_KEY_FILE")
+	diags.Append(d...)
+
+	switch {
+	case len(clientCert) > 0 && len(clientKey) == 0:
+		diags.AddAttributeError(
+			keyPath,
+			"Missing opencti client key",
+			"A client certificate was provided without its private key. "+
+				"Set the client_key or client_key_file value in the configuration, or use the OPENCTI_CLIENT_KEY or OPENCTI_CLIENT_KEY_FILE environment variable.",
+		)
+	case len(clientCert) == 0 && len(clientKey) > 0:
+		diags.AddAttributeError(
+			certPath,
+			"Missing opencti client certificate",
+			"A client key was provided without its certificate. "+
+				"Set the client_cert or client_cert_file value in the configuration, or use the OPENCTI_CLIENT_CERT or OPENCTI_CLIENT_CERT_FILE environment variable.",
+		)
+	case len(clientCert) > 0 && len(clientKey) > 0:
+		cert, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			diags.AddAttributeError(
+				certPath,
+				"Invalid opencti client certificate",
+				"The provider could not load the client certificate and key pair: "+err.Error(),
+			)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		transport = &http.Transport{}
+	}
+
+	transport = transport.Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	// Explicit proxy, the standard HTTP(S)_PROXY variables are used otherwise
+	proxyURL := os.Getenv("OPENCTI_PROXY_URL")
+	noProxy := os.Getenv("OPENCTI_NO_PROXY")
+
+	if !config.ProxyURL.IsNull() {
+		proxyURL = config.ProxyURL.ValueString()
+	}
+
+	if !config.NoProxy.IsNull() {
+		noProxy = config.NoProxy.ValueString()
+	}
+
+	if proxyURL != "" {
+		if _, err := url.Parse(proxyURL); err != nil {
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid opencti proxy URL",
+				"The provider could not parse the proxy URL: "+err.Error(),
+			)
+		}
+
+		proxyConfig := &httpproxy.Config{
+			HTTPProxy:  proxyURL,
+			HTTPSProxy: proxyURL,
+			NoProxy:    noProxy,
+		}
+		proxyFunc := proxyConfig.ProxyFunc()
+
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+
+	return transport, diags
+}
+
+// headerTransport adds a fixed set of headers to every request sent to opencti.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.headers) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the original request
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// timeoutTransport bounds every attempt of a request sent to opencti, including the read of
+// its response. It is the innermost transport so that the waits for the request limits and
+// between the retries are only bounded by the timeouts of the operations.
+type timeoutTransport struct {
+	base http.RoundTripper
+	// timeout is the maximum duration of an attempt, zero when the attempts are not bounded
+	timeout time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	// The attempt lasts until its response is read
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelBody cancels the context of an attempt once the response body is closed.
+type cancelBody struct {
+	io.ReadCloser
+
+	once   sync.Once
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer.
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.cancel)
+
+	return err
+}
+
+// loadPEM returns the PEM content of a setting which can either be given inline or
+// through a file, along with the attribute path to report errors on. The configuration
+// overrides the environment variables, the inline content and the file conflict when
+// they are both set in the configuration or both set in the environment.
+func loadPEM(inline types.String, inlineAttr, inlineEnv string, file types.String, fileAttr, fileEnv string) ([]byte, path.Path, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	content := os.Getenv(inlineEnv)
+	filename := os.Getenv(fileEnv)
+	source := "with the " + inlineEnv + " and " + fileEnv + " environment variables"
+
+	if !inline.IsNull() || !file.IsNull() {
+		content = inline.ValueString()
+		filename = file.ValueString()
+		source = "in the configuration"
+	}
+
+	if content != "" && filename != "" {
+		diags.AddAttributeError(
+			path.Root(inlineAttr),
+			"Conflicting opencti "+inlineAttr+" configuration",
+			"Only one of "+inlineAttr+" and "+fileAttr+" can be set, they are both set "+source+".",
+		)
+
+		return nil, path.Root(inlineAttr), diags
+	}
+
+	if filename == "" {
+		return []byte(content), path.Root(inlineAttr), diags
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(fileAttr),
+			"Unable to read opencti "+fileAttr,
+			"The provider could not read the file "+filename+": "+err.Error(),
+		)
+
+		return nil, path.Root(fileAttr), diags
+	}
+
+	return data, path.Root(fileAttr), diags
+}
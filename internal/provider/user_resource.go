@@ -18,16 +18,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/weisshorn-cyd/gocti"
 	"github.com/weisshorn-cyd/gocti/graphql"
 	"github.com/weisshorn-cyd/gocti/system"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &userResource{}
-	_ resource.ResourceWithConfigure   = &userResource{}
-	_ resource.ResourceWithImportState = &userResource{}
+	_ resource.Resource                   = &userResource{}
+	_ resource.ResourceWithConfigure      = &userResource{}
+	_ resource.ResourceWithImportState    = &userResource{}
+	_ resource.ResourceWithModifyPlan     = &userResource{}
+	_ resource.ResourceWithValidateConfig = &userResource{}
 )
 
 // NewUserResource is a helper function to simplify the provider implementation.
@@ -37,7 +38,7 @@ func NewUserResource() resource.Resource {
 
 // userResource is the resource implementation.
 type userResource struct {
-	client *gocti.OpenCTIAPIClient
+	data *openctiProviderData
 }
 
 // userResourceModel maps the resource schema data.
@@ -48,9 +49,17 @@ type userResourceModel struct {
 	APIToken            types.String `tfsdk:"api_token"`
 	Groups              types.List   `tfsdk:"groups"`
 	UserConfidenceLevel types.Object `tfsdk:"user_confidence_level"`
+	DefaultedAttributes types.List   `tfsdk:"defaulted_attributes"`
+	ImpersonateUser     types.String `tfsdk:"impersonate_user"`
+	Timeouts            types.Object `tfsdk:"timeouts"`
 	LastUpdated         types.String `tfsdk:"last_updated"`
 }
 
+// userRequirement is the minimum platform supporting the user confidence level, introduced in opencti 6.0.
+var userRequirement = platformRequirement{
+	MinVersion: "6.0.0",
+}
+
 // Metadata returns the resource type name.
 func (r *userResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_user"
@@ -89,7 +98,7 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"user_confidence_level": schema.SingleNestedAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "User confidence configuration (defaults to max_confidence = 100).",
+				MarkdownDescription: "User confidence configuration (defaults to the user `defaults` of the provider, or to max_confidence = 100).",
 
 				Attributes: map[string]schema.Attribute{
 					"max_confidence": schema.Int64Attribute{
@@ -115,12 +124,24 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					objectplanmodifier.RequiresReplace(),
 				},
 			},
+			"defaulted_attributes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Names of the attributes whose value comes from the `defaults` of the provider.",
+			},
+			"impersonate_user": impersonateUserAttribute(),
+			"timeouts":         timeoutsAttribute(),
 		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "create", "opencti_user") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan userResourceModel
 
@@ -131,9 +152,22 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "create", "opencti_user")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, "Creating user")
 
-	usersRaw, err := r.client.ListUsers(ctx, "", true, nil)
+	createdUser := system.User{}
+
+	// Check if the user exist, if so, retrieve it
+	userID, found, err := r.data.lookupID(ctx, lookupUser, plan.UserEmail.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading opencti users",
@@ -143,16 +177,18 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	createdUser := system.User{}
-
-	// Check if the user exist, if so, retrieve it
-	for _, user := range usersRaw {
-		if plan.Name.ValueString() == user.Name {
-			tflog.Info(ctx, fmt.Sprintf("User already exist: %+v", user))
-			createdUser = user
+	if found {
+		createdUser, err = r.data.client.ReadUser(ctx, "id name user_email api_token user_confidence_level { max_confidence overrides { entity_type max_confidence } }", userID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading opencti user",
+				"Could not read opencti user, unexpected error: "+err.Error(),
+			)
 
-			break
+			return
 		}
+
+		tflog.Info(ctx, fmt.Sprintf("User already exist: %+v", createdUser))
 	}
 
 	overrideObjectType := types.ObjectType{
@@ -245,9 +281,9 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if createdUser.Name == "" {
 		tflog.Info(ctx, "User does not exist, creating")
 
-		createdUser, err = r.client.CreateUser(ctx, "id name user_email api_token user_confidence_level { max_confidence overrides { entity_type max_confidence } }", system.UserAddInput{
+		createdUser, err = r.data.client.CreateUser(ctx, "id name user_email api_token user_confidence_level { max_confidence overrides { entity_type max_confidence } }", system.UserAddInput{
 			UserEmail:           plan.UserEmail.ValueString(),
-			Name:                plan.Name.ValueString(),
+			Name:                r.data.managedName(plan.Name.ValueString()),
 			Password:            uuid.New().String(),
 			UserConfidenceLevel: conf,
 		})
@@ -259,16 +295,8 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 			return
 		}
-	}
-
-	existingGroups, err := r.client.ListGroups(ctx, "id name", true, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error listing groups",
-			"Could not create user, unexpected error: "+err.Error(),
-		)
 
-		return
+		r.data.cache.set(lookupUser, createdUser.UserEmail, createdUser.ID)
 	}
 
 	groupsAssigned := []string{}
@@ -277,21 +305,31 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	for _, group := range plan.Groups.Elements() {
 		tflog.Info(ctx, fmt.Sprintf("Assigning group %s to user %s", group, createdUser.Name))
 
-		for _, remoteGroup := range existingGroups {
-			if strings.Trim(group.String(), "\"") == remoteGroup.Name {
-				if _, err := createdUser.AssignGroup(ctx, r.client, remoteGroup.ID); err != nil {
-					resp.Diagnostics.AddError(
-						"Error assigning group to user", err.Error(),
-					)
+		groupName := strings.Trim(group.String(), "\"")
+
+		groupID, found, err := r.data.lookupNamedID(ctx, lookupGroup, groupName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error listing groups",
+				"Could not create user, unexpected error: "+err.Error(),
+			)
+
+			return
+		}
 
-					return
-				}
+		if !found {
+			continue
+		}
 
-				groupsAssigned = append(groupsAssigned, remoteGroup.Name)
+		if _, err := createdUser.AssignGroup(ctx, r.data.client, groupID); err != nil {
+			resp.Diagnostics.AddError(
+				"Error assigning group to user", err.Error(),
+			)
 
-				break
-			}
+			return
 		}
+
+		groupsAssigned = append(groupsAssigned, groupName)
 	}
 
 	sort.Strings(groupsAssigned)
@@ -305,11 +343,14 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	plan = userResourceModel{
 		ID:                  types.StringValue(createdUser.ID),
-		Name:                types.StringValue(createdUser.Name),
+		Name:                types.StringValue(r.data.stateName(createdUser.Name)),
 		UserEmail:           types.StringValue(createdUser.UserEmail),
 		APIToken:            types.StringValue(createdUser.ApiToken),
 		Groups:              groupsAssignedList,
 		UserConfidenceLevel: userConfidenceLevel,
+		DefaultedAttributes: plan.DefaultedAttributes,
+		ImpersonateUser:     plan.ImpersonateUser,
+		Timeouts:            plan.Timeouts,
 	}
 
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
@@ -335,8 +376,12 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "read", "opencti_user")
+
 	// Read user from opencti
-	user, err := r.client.ReadUser(ctx, "id name user_email api_token user_confidence_level { max_confidence overrides { entity_type max_confidence } } groups { edges { node {id name} } }", state.ID.ValueString())
+	user, err := r.data.client.ReadUser(ctx, "id name user_email api_token user_confidence_level { max_confidence overrides { entity_type max_confidence } } groups { edges { node {id name} } }", state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading opencti user", err.Error(),
@@ -350,7 +395,7 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Format the groups
 	groups := []string{}
 	for _, group := range user.Groups.Edges {
-		groups = append(groups, group.Node.Name)
+		groups = append(groups, r.data.stateName(group.Node.Name))
 	}
 
 	sort.Strings(groups)
@@ -360,7 +405,7 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	userConfidenceLevel := convertUserConfidenceLevel(user.UserConfidenceLevel)
 
 	state.ID = types.StringValue(user.ID)
-	state.Name = types.StringValue(user.Name)
+	state.Name = types.StringValue(r.data.stateName(user.Name))
 	state.UserEmail = types.StringValue(user.UserEmail)
 	state.APIToken = types.StringValue(user.ApiToken)
 	state.Groups = groupsList
@@ -377,6 +422,11 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "update", "opencti_user") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan userResourceModel
 
@@ -387,7 +437,17 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	user, err := r.client.ReadUser(ctx, "id name user_email api_token groups { edges { node {id name} } }", plan.ID.ValueString())
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "update", "opencti_user")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.data.client.ReadUser(ctx, "id name user_email api_token groups { edges { node {id name} } }", plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading opencti user", err.Error(),
@@ -411,10 +471,12 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	// Remove groups
 	for _, group := range user.Groups.Edges {
-		if !slices.Contains(groupsPlan, group.Node.Name) {
-			tflog.Info(ctx, fmt.Sprintf("Removing group: %s", group.Node.Name))
+		name := r.data.stateName(group.Node.Name)
+
+		if !slices.Contains(groupsPlan, name) {
+			tflog.Info(ctx, fmt.Sprintf("Removing group: %s", name))
 
-			if _, err := user.UnassignGroup(ctx, r.client, group.Node.ID); err != nil {
+			if _, err := user.UnassignGroup(ctx, r.data.client, group.Node.ID); err != nil {
 				resp.Diagnostics.AddError(
 					"Error Unassigning OpenCTI Group from User", err.Error(),
 				)
@@ -422,39 +484,37 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 				return
 			}
 		} else {
-			groupsOfUser = append(groupsOfUser, group.Node.Name)
+			groupsOfUser = append(groupsOfUser, name)
 		}
 	}
 
 	// Add groups
-	existingGroups, err := r.client.ListGroups(ctx, "id name", true, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error listing groups", err.Error(),
-		)
-
-		return
-	}
-
 	for _, group := range groupsPlan {
 		if !slices.Contains(groupsOfUser, group) {
 			tflog.Info(ctx, fmt.Sprintf("Adding group: %s", group))
 
-			for _, remoteGroup := range existingGroups {
-				if group == remoteGroup.Name {
-					if _, err := user.AssignGroup(ctx, r.client, remoteGroup.ID); err != nil {
-						resp.Diagnostics.AddError(
-							"Error assigning group to user", err.Error(),
-						)
+			groupID, found, err := r.data.lookupNamedID(ctx, lookupGroup, group)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error listing groups", err.Error(),
+				)
+
+				return
+			}
 
-						return
-					}
+			if !found {
+				continue
+			}
 
-					groupsOfUser = append(groupsOfUser, remoteGroup.Name)
+			if _, err := user.AssignGroup(ctx, r.data.client, groupID); err != nil {
+				resp.Diagnostics.AddError(
+					"Error assigning group to user", err.Error(),
+				)
 
-					break
-				}
+				return
 			}
+
+			groupsOfUser = append(groupsOfUser, group)
 		}
 	}
 
@@ -479,6 +539,11 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "delete", "opencti_user") {
+		return
+	}
+
 	// Retrieve values from state
 	var state userResourceModel
 
@@ -489,13 +554,25 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	if _, err := r.client.DeleteUser(ctx, state.ID.ValueString()); err != nil {
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "delete", "opencti_user")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, state.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.data.client.DeleteUser(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting OpenCTI User", err.Error(),
 		)
 
 		return
 	}
+
+	r.data.cache.remove(lookupUser, state.UserEmail.ValueString())
 }
 
 // Configure adds the provider configured client to the resource.
@@ -506,17 +583,43 @@ func (r *userResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*gocti.OpenCTIAPIClient)
+	data, ok := req.ProviderData.(*openctiProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *gocti.OpenCTIAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.data = data
+}
+
+// ModifyPlan merges the provider defaults into the plan, then checks that the provider allows
+// the planned change.
+func (r *userResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the provider is not configured, e.g. when its configuration is deferred
+	if r.data == nil {
+		return
+	}
+
+	// Merge the provider defaults into the plan, before checking the planned change
+	if !req.Plan.Raw.IsNull() {
+		r.data.applyUserDefaults(ctx, req, resp)
+	}
+
+	resp.Diagnostics.Append(r.data.checkReadOnlyPlan(req.State, resp.Plan, "opencti_user")...)
+}
+
+// ValidateConfig checks that the platform supports the resource.
+func (r *userResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.platform.check(userRequirement, "opencti_user")...)
 }
 
 func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
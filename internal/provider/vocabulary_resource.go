@@ -12,15 +12,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/weisshorn-cyd/gocti"
 	"github.com/weisshorn-cyd/gocti/entity"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &vocabularyResource{}
-	_ resource.ResourceWithConfigure   = &vocabularyResource{}
-	_ resource.ResourceWithImportState = &vocabularyResource{}
+	_ resource.Resource                   = &vocabularyResource{}
+	_ resource.ResourceWithConfigure      = &vocabularyResource{}
+	_ resource.ResourceWithImportState    = &vocabularyResource{}
+	_ resource.ResourceWithModifyPlan     = &vocabularyResource{}
+	_ resource.ResourceWithValidateConfig = &vocabularyResource{}
 )
 
 // NewVocabularyResource is a helper function to simplify the provider implementation.
@@ -30,16 +31,23 @@ func NewVocabularyResource() resource.Resource {
 
 // vocabularyResource is the resource implementation.
 type vocabularyResource struct {
-	client *gocti.OpenCTIAPIClient
+	data *openctiProviderData
 }
 
 // vocabularyResourceModel maps the resource schema data.
 type vocabularyResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Category    types.String `tfsdk:"category"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	Category        types.String `tfsdk:"category"`
+	ImpersonateUser types.String `tfsdk:"impersonate_user"`
+	Timeouts        types.Object `tfsdk:"timeouts"`
+	LastUpdated     types.String `tfsdk:"last_updated"`
+}
+
+// vocabularyRequirement is the minimum platform supporting the vocabularies.
+var vocabularyRequirement = platformRequirement{
+	MinVersion: minPlatformVersion,
 }
 
 // Metadata returns the resource type name.
@@ -69,12 +77,19 @@ func (r *vocabularyResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"category": schema.StringAttribute{
 				Required: true,
 			},
+			"impersonate_user": impersonateUserAttribute(),
+			"timeouts":         timeoutsAttribute(),
 		},
 	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *vocabularyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "create", "opencti_vocabulary") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan vocabularyResourceModel
 
@@ -85,12 +100,22 @@ func (r *vocabularyResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "create", "opencti_vocabulary")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, "Creating vocabulary")
 
 	// Create new vocabulary
-	createdVoc, err := r.client.CreateVocabulary(ctx, "id name description category { key }", entity.VocabularyAddInput{
-		Name:        plan.Name.ValueString(),
-		Description: plan.Description.ValueString(),
+	createdVoc, err := r.data.client.CreateVocabulary(ctx, "id name description category { key }", entity.VocabularyAddInput{
+		Name:        r.data.managedName(plan.Name.ValueString()),
+		Description: r.data.markDescription(plan.Description.ValueString()),
 		Category:    plan.Category.ValueString(),
 	})
 	if err != nil {
@@ -105,8 +130,8 @@ func (r *vocabularyResource) Create(ctx context.Context, req resource.CreateRequ
 	tflog.Info(ctx, fmt.Sprintf("Vocabulary created: %+v", createdVoc))
 
 	plan.ID = types.StringValue(createdVoc.ID)
-	plan.Name = types.StringValue(createdVoc.Name)
-	plan.Description = types.StringValue(createdVoc.Description)
+	plan.Name = types.StringValue(r.data.stateName(createdVoc.Name))
+	plan.Description = types.StringValue(r.data.unmarkDescription(createdVoc.Description))
 	plan.Category = types.StringValue(createdVoc.Category.Key)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
@@ -131,8 +156,12 @@ func (r *vocabularyResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "read", "opencti_vocabulary")
+
 	// Read vocabulary from opencti
-	voc, err := r.client.ReadVocabulary(ctx, "id name description category { key }", state.ID.ValueString())
+	voc, err := r.data.client.ReadVocabulary(ctx, "id name description category { key }", state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading opencti vocabulary", err.Error(),
@@ -144,8 +173,8 @@ func (r *vocabularyResource) Read(ctx context.Context, req resource.ReadRequest,
 	tflog.Info(ctx, fmt.Sprintf("Vocabulary read: %+v", voc))
 
 	state.ID = types.StringValue(voc.ID)
-	state.Name = types.StringValue(voc.Name)
-	state.Description = types.StringValue(voc.Description)
+	state.Name = types.StringValue(r.data.stateName(voc.Name))
+	state.Description = types.StringValue(r.data.unmarkDescription(voc.Description))
 	state.Category = types.StringValue(voc.Category.Key)
 
 	// Set refreshed state
@@ -159,6 +188,11 @@ func (r *vocabularyResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *vocabularyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "update", "opencti_vocabulary") {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan vocabularyResourceModel
 
@@ -169,12 +203,22 @@ func (r *vocabularyResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "update", "opencti_vocabulary")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, plan.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, "Updating vocabulary")
 
 	// Create vocabulary with new values
-	createdVoc, err := r.client.CreateVocabulary(ctx, "", entity.VocabularyAddInput{
-		Name:        plan.Name.ValueString(),
-		Description: plan.Description.ValueString(),
+	createdVoc, err := r.data.client.CreateVocabulary(ctx, "", entity.VocabularyAddInput{
+		Name:        r.data.managedName(plan.Name.ValueString()),
+		Description: r.data.markDescription(plan.Description.ValueString()),
 		Category:    plan.Category.ValueString(),
 	})
 	if err != nil {
@@ -189,8 +233,8 @@ func (r *vocabularyResource) Update(ctx context.Context, req resource.UpdateRequ
 	tflog.Info(ctx, fmt.Sprintf("Vocabulary created: %+v", createdVoc))
 
 	plan.ID = types.StringValue(createdVoc.ID)
-	plan.Name = types.StringValue(createdVoc.Name)
-	plan.Description = types.StringValue(createdVoc.Description)
+	plan.Name = types.StringValue(r.data.stateName(createdVoc.Name))
+	plan.Description = types.StringValue(r.data.unmarkDescription(createdVoc.Description))
 	plan.Category = types.StringValue(createdVoc.Category.Key)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
@@ -205,6 +249,11 @@ func (r *vocabularyResource) Update(ctx context.Context, req resource.UpdateRequ
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *vocabularyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Refuse any change when the provider is read-only
+	if r.data.denyMutation(&resp.Diagnostics, "delete", "opencti_vocabulary") {
+		return
+	}
+
 	// Retrieve values from state
 	var state vocabularyResourceModel
 
@@ -215,7 +264,17 @@ func (r *vocabularyResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	if _, err := r.client.DeleteVocabulary(ctx, state.ID.ValueString()); err != nil {
+	ctx, cancel := withTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	defer checkTimeout(ctx, &resp.Diagnostics, "delete", "opencti_vocabulary")
+
+	ctx = impersonate(ctx, &resp.Diagnostics, r.data, state.ImpersonateUser)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.data.client.DeleteVocabulary(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting OpenCTI Vocabulary",
 			"Could not delete vocabulary, unexpected error: "+err.Error(),
@@ -233,17 +292,37 @@ func (r *vocabularyResource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*gocti.OpenCTIAPIClient)
+	data, ok := req.ProviderData.(*openctiProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *gocti.OpenCTIAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *openctiProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.data = data
+}
+
+// ValidateConfig checks that the platform supports the resource.
+func (r *vocabularyResource) ValidateConfig(_ context.Context, _ resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Nothing to check when the provider is not configured yet, e.g. on terraform validate
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.platform.check(vocabularyRequirement, "opencti_vocabulary")...)
+}
+
+// ModifyPlan checks that the provider allows the planned change.
+func (r *vocabularyResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the provider is not configured, e.g. when its configuration is deferred
+	if r.data == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.data.checkReadOnlyPlan(req.State, resp.Plan, "opencti_vocabulary")...)
 }
 
 func (r *vocabularyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {